@@ -0,0 +1,218 @@
+package vector
+
+import "math"
+
+// AABB3 is an axis aligned bounding box from Min to Max.
+type AABB3 struct {
+	Min V3
+	Max V3
+}
+
+// EmptyAABB3 returns a box containing nothing, which is handy to start
+// with and then Extend.  Its Min is +∞ and its Max is -∞.
+func EmptyAABB3() AABB3 {
+	inf := math.Inf(1)
+	return AABB3{V3{inf, inf, inf}, V3{-inf, -inf, -inf}}
+}
+
+// PointsAABB3 returns the smallest box enclosing all of the points.
+func PointsAABB3(points []V3) AABB3 {
+	b := EmptyAABB3()
+	for _, p := range points {
+		b = b.Extend(p)
+	}
+	return b
+}
+
+// IsEmpty is true if the box doesn't contain any points at all.
+func (b AABB3) IsEmpty() bool {
+	return b.Min.X > b.Max.X || b.Min.Y > b.Max.Y || b.Min.Z > b.Max.Z
+}
+
+// Extend grows the box to include p.
+func (b AABB3) Extend(p V3) AABB3 {
+	return AABB3{b.Min.Min(p), b.Max.Max(p)}
+}
+
+// Union returns the smallest box enclosing both boxes.
+func (b AABB3) Union(a AABB3) AABB3 {
+	return AABB3{b.Min.Min(a.Min), b.Max.Max(a.Max)}
+}
+
+// Intersection returns the box where both boxes overlap.  If they don't
+// overlap the result IsEmpty.
+func (b AABB3) Intersection(a AABB3) AABB3 {
+	return AABB3{b.Min.Max(a.Min), b.Max.Min(a.Max)}
+}
+
+// Contains is true if p is inside the box or on its surface.
+func (b AABB3) Contains(p V3) bool {
+	return p.X >= b.Min.X && p.X <= b.Max.X &&
+		p.Y >= b.Min.Y && p.Y <= b.Max.Y &&
+		p.Z >= b.Min.Z && p.Z <= b.Max.Z
+}
+
+// ContainsAABB3 is true if a is entirely inside b.
+func (b AABB3) ContainsAABB3(a AABB3) bool {
+	return b.Contains(a.Min) && b.Contains(a.Max)
+}
+
+// Overlaps is true if the boxes share any space, including just touching.
+func (b AABB3) Overlaps(a AABB3) bool {
+	return b.Min.X <= a.Max.X && b.Max.X >= a.Min.X &&
+		b.Min.Y <= a.Max.Y && b.Max.Y >= a.Min.Y &&
+		b.Min.Z <= a.Max.Z && b.Max.Z >= a.Min.Z
+}
+
+func (b AABB3) Center() V3 {
+	return b.Min.Add(b.Max).Scale(0.5)
+}
+
+// Size is the width, height and depth of the box.
+func (b AABB3) Size() V3 {
+	return b.Max.Sub(b.Min)
+}
+
+// Extents is half the Size, ie. the distance from the Center to the faces.
+func (b AABB3) Extents() V3 {
+	return b.Size().Scale(0.5)
+}
+
+func (b AABB3) SurfaceArea() float64 {
+	if b.IsEmpty() {
+		return 0
+	}
+	s := b.Size()
+	return 2 * (s.X*s.Y + s.Y*s.Z + s.Z*s.X)
+}
+
+func (b AABB3) Volume() float64 {
+	if b.IsEmpty() {
+		return 0
+	}
+	s := b.Size()
+	return s.X * s.Y * s.Z
+}
+
+// ClosestPoint returns the point in the box nearest to p.
+// If p is inside the box, that's just p.
+func (b AABB3) ClosestPoint(p V3) V3 {
+	return p.Max(b.Min).Min(b.Max)
+}
+
+// Transform returns the box enclosing this box after it has been
+// transformed by an affine matrix, using Arvo's method.  This is much
+// cheaper than transforming all 8 corners, and gives the same result.
+func (b AABB3) Transform(m M44) AABB3 {
+	if b.IsEmpty() {
+		return b
+	}
+
+	min := [3]float64{b.Min.X, b.Min.Y, b.Min.Z}
+	max := [3]float64{b.Max.X, b.Max.Y, b.Max.Z}
+	omin := [3]float64{m[12], m[13], m[14]}
+	omax := omin
+
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 3; j++ {
+			e := m[j*4+i]
+			a := e * min[j]
+			c := e * max[j]
+			if a < c {
+				omin[i] += a
+				omax[i] += c
+			} else {
+				omin[i] += c
+				omax[i] += a
+			}
+		}
+	}
+
+	return AABB3{
+		V3{omin[0], omin[1], omin[2]},
+		V3{omax[0], omax[1], omax[2]},
+	}
+}
+
+// AABB2 is a 2D axis aligned bounding box from Min to Max.
+type AABB2 struct {
+	Min V2
+	Max V2
+}
+
+// EmptyAABB2 returns a box containing nothing.  See EmptyAABB3.
+func EmptyAABB2() AABB2 {
+	inf := math.Inf(1)
+	return AABB2{V2{inf, inf}, V2{-inf, -inf}}
+}
+
+// PointsAABB2 returns the smallest box enclosing all of the points.
+func PointsAABB2(points []V2) AABB2 {
+	b := EmptyAABB2()
+	for _, p := range points {
+		b = b.Extend(p)
+	}
+	return b
+}
+
+func (b AABB2) IsEmpty() bool {
+	return b.Min.X > b.Max.X || b.Min.Y > b.Max.Y
+}
+
+func (b AABB2) Extend(p V2) AABB2 {
+	return AABB2{b.Min.Min(p), b.Max.Max(p)}
+}
+
+func (b AABB2) Union(a AABB2) AABB2 {
+	return AABB2{b.Min.Min(a.Min), b.Max.Max(a.Max)}
+}
+
+func (b AABB2) Intersection(a AABB2) AABB2 {
+	return AABB2{b.Min.Max(a.Min), b.Max.Min(a.Max)}
+}
+
+func (b AABB2) Contains(p V2) bool {
+	return p.X >= b.Min.X && p.X <= b.Max.X &&
+		p.Y >= b.Min.Y && p.Y <= b.Max.Y
+}
+
+func (b AABB2) ContainsAABB2(a AABB2) bool {
+	return b.Contains(a.Min) && b.Contains(a.Max)
+}
+
+func (b AABB2) Overlaps(a AABB2) bool {
+	return b.Min.X <= a.Max.X && b.Max.X >= a.Min.X &&
+		b.Min.Y <= a.Max.Y && b.Max.Y >= a.Min.Y
+}
+
+func (b AABB2) Center() V2 {
+	return b.Min.Add(b.Max).Scale(0.5)
+}
+
+func (b AABB2) Size() V2 {
+	return b.Max.Sub(b.Min)
+}
+
+func (b AABB2) Extents() V2 {
+	return b.Size().Scale(0.5)
+}
+
+func (b AABB2) Perimeter() float64 {
+	if b.IsEmpty() {
+		return 0
+	}
+	s := b.Size()
+	return 2 * (s.X + s.Y)
+}
+
+func (b AABB2) Area() float64 {
+	if b.IsEmpty() {
+		return 0
+	}
+	s := b.Size()
+	return s.X * s.Y
+}
+
+func (b AABB2) ClosestPoint(p V2) V2 {
+	return p.Max(b.Min).Min(b.Max)
+}
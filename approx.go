@@ -0,0 +1,147 @@
+package vector
+
+import "math"
+
+// ApproxEqual reports whether a and b are within tolerance of each other.
+// An absolute tolerance is what you want when comparing against zero.
+// NaN is never equal to anything.
+func ApproxEqual(a, b, tolerance float64) bool {
+	if a == b {
+		// infinities, which are NaN apart
+		return true
+	}
+	return math.Abs(a-b) <= tolerance
+}
+
+// ApproxEqualULP reports whether a and b are at most ulps representable
+// values apart, which is a tolerance relative to their size.  It's no good
+// near zero, where the representable values are packed very closely; use
+// ApproxEqual there.  NaN is never equal to anything.
+func ApproxEqualULP(a, b float64, ulps uint64) bool {
+	if a == b {
+		return true
+	}
+	if math.IsNaN(a) || math.IsNaN(b) {
+		return false
+	}
+	return ulpDist(a, b) <= ulps
+}
+
+// ulpDist counts the representable values between a and b.
+func ulpDist(a, b float64) uint64 {
+	// with the sign bit off the bits of a float sort the same way the
+	// float does
+	ma := uint64(math.Float64bits(math.Abs(a)))
+	mb := uint64(math.Float64bits(math.Abs(b)))
+	if math.Signbit(a) != math.Signbit(b) {
+		return ma + mb
+	}
+	if ma > mb {
+		return ma - mb
+	}
+	return mb - ma
+}
+
+// ApproxEqual compares each component with the absolute tolerance.
+func (v V2) ApproxEqual(a V2, tolerance float64) bool {
+	return ApproxEqual(v.X, a.X, tolerance) && ApproxEqual(v.Y, a.Y, tolerance)
+}
+
+// ApproxEqualULP compares each component with ApproxEqualULP.
+func (v V2) ApproxEqualULP(a V2, ulps uint64) bool {
+	return ApproxEqualULP(v.X, a.X, ulps) && ApproxEqualULP(v.Y, a.Y, ulps)
+}
+
+// ApproxEqual compares each component with the absolute tolerance.
+func (v V3) ApproxEqual(a V3, tolerance float64) bool {
+	return ApproxEqual(v.X, a.X, tolerance) &&
+		ApproxEqual(v.Y, a.Y, tolerance) &&
+		ApproxEqual(v.Z, a.Z, tolerance)
+}
+
+// ApproxEqualULP compares each component with ApproxEqualULP.
+func (v V3) ApproxEqualULP(a V3, ulps uint64) bool {
+	return ApproxEqualULP(v.X, a.X, ulps) &&
+		ApproxEqualULP(v.Y, a.Y, ulps) &&
+		ApproxEqualULP(v.Z, a.Z, ulps)
+}
+
+// ApproxEqual compares each component with the absolute tolerance.
+func (v V4) ApproxEqual(a V4, tolerance float64) bool {
+	return ApproxEqual(v.X, a.X, tolerance) &&
+		ApproxEqual(v.Y, a.Y, tolerance) &&
+		ApproxEqual(v.Z, a.Z, tolerance) &&
+		ApproxEqual(v.W, a.W, tolerance)
+}
+
+// ApproxEqualULP compares each component with ApproxEqualULP.
+func (v V4) ApproxEqualULP(a V4, ulps uint64) bool {
+	return ApproxEqualULP(v.X, a.X, ulps) &&
+		ApproxEqualULP(v.Y, a.Y, ulps) &&
+		ApproxEqualULP(v.Z, a.Z, ulps) &&
+		ApproxEqualULP(v.W, a.W, ulps)
+}
+
+// ApproxEqual compares each component with the absolute tolerance.  q and
+// -q are the same rotation, so they count as equal.
+func (q Q) ApproxEqual(a Q, tolerance float64) bool {
+	eq := func(b Q) bool {
+		return ApproxEqual(q.R, b.R, tolerance) &&
+			ApproxEqual(q.I, b.I, tolerance) &&
+			ApproxEqual(q.J, b.J, tolerance) &&
+			ApproxEqual(q.K, b.K, tolerance)
+	}
+	return eq(a) || eq(a.Scale(-1))
+}
+
+// ApproxEqualULP compares each component with ApproxEqualULP.  q and -q
+// are the same rotation, so they count as equal.
+func (q Q) ApproxEqualULP(a Q, ulps uint64) bool {
+	eq := func(b Q) bool {
+		return ApproxEqualULP(q.R, b.R, ulps) &&
+			ApproxEqualULP(q.I, b.I, ulps) &&
+			ApproxEqualULP(q.J, b.J, ulps) &&
+			ApproxEqualULP(q.K, b.K, ulps)
+	}
+	return eq(a) || eq(a.Scale(-1))
+}
+
+// ApproxEqual compares each element with the absolute tolerance.
+func (m M33) ApproxEqual(a M33, tolerance float64) bool {
+	for i := range m {
+		if !ApproxEqual(m[i], a[i], tolerance) {
+			return false
+		}
+	}
+	return true
+}
+
+// ApproxEqualULP compares each element with ApproxEqualULP.
+func (m M33) ApproxEqualULP(a M33, ulps uint64) bool {
+	for i := range m {
+		if !ApproxEqualULP(m[i], a[i], ulps) {
+			return false
+		}
+	}
+	return true
+}
+
+// ApproxEqual compares each element with the absolute tolerance.
+func (m M44) ApproxEqual(a M44, tolerance float64) bool {
+	for i := range m {
+		if !ApproxEqual(m[i], a[i], tolerance) {
+			return false
+		}
+	}
+	return true
+}
+
+// ApproxEqualULP compares each element with ApproxEqualULP.
+func (m M44) ApproxEqualULP(a M44, ulps uint64) bool {
+	for i := range m {
+		if !ApproxEqualULP(m[i], a[i], ulps) {
+			return false
+		}
+	}
+	return true
+}
@@ -1,5 +1,7 @@
 package vector
 
+import "math"
+
 type Camera struct {
 	// actual screen resolution (for unprojecting!)
 	Width  float64
@@ -13,6 +15,25 @@ type Camera struct {
 	// View frustum
 	View Frustum
 
+	// Ortho blends from a perspective projection (0) to an orthographic one
+	// (1).  Animate it for a smooth switch between the two.
+	Ortho float64
+
+	// OrthoHeight is how many world units fit between the bottom and the
+	// top of the screen in orthographic mode.  See MatchOrthoHeight and
+	// FitOrtho.
+	OrthoHeight float64
+
+	// InfiniteFar pushes the perspective far plane out to infinity.  Far is
+	// still used for the orthographic projection, and by Unproject.
+	InfiniteFar bool
+
+	// ReverseZ maps depth from 1 at the near plane to 0 at the far plane,
+	// for much better depth precision.  This only makes sense with a 0 to 1
+	// clip space depth range (glClipControl, Vulkan, Direct3D).  Without it
+	// depth runs from -1 at near to 1 at far, OpenGL style.
+	ReverseZ bool
+
 	Projection       M44
 	ModelView        M44
 	ModelViewInverse M44
@@ -26,11 +47,57 @@ type Camera struct {
 	ModelViewProjection M44
 }
 
-// Before calling this, set cam Width, Height, YFov, Near, and Far
+// Before calling this, set cam Width, Height, YFov, Near, and Far,
+// and optionally Ortho, OrthoHeight, InfiniteFar and ReverseZ.
 func (cam *Camera) SetupViewProjection() {
 	x_ratio := cam.Width / cam.Height
 	cam.View = PerspectiveFrustum(cam.YFov, x_ratio, cam.Near, cam.Far)
 	cam.Projection = cam.View.M44()
+
+	if cam.InfiniteFar {
+		cam.Projection[10] = -1
+		cam.Projection[14] = -2 * cam.Near
+	}
+
+	if cam.Ortho != 0 {
+		h := cam.OrthoHeight / 2
+		w := h * x_ratio
+		o := Ortho(-w, w, -h, h, cam.Near, cam.Far)
+		for i := range cam.Projection {
+			cam.Projection[i] += (o[i] - cam.Projection[i]) * cam.Ortho
+		}
+	}
+
+	if cam.ReverseZ {
+		// remap depth from -1..1 to 1..0
+		for i := 2; i < 16; i += 4 {
+			cam.Projection[i] = (cam.Projection[i+1] - cam.Projection[i]) / 2
+		}
+	}
+
+	cam.ModelViewProjection = cam.Projection.Mult(cam.ModelView)
+}
+
+// MatchOrthoHeight sets OrthoHeight so that things at distance from the
+// camera are the same size in both perspective and orthographic modes.
+// Call SetupViewProjection first, and again afterwards.
+func (cam *Camera) MatchOrthoHeight(distance float64) {
+	cam.OrthoHeight = distance * (cam.View.Top - cam.View.Bottom) / cam.View.Near
+}
+
+// FitOrtho slides the camera sideways and sets OrthoHeight so that the box
+// just fills the screen in orthographic mode, without changing which way
+// the camera is looking.  It calls SetupModelView and SetupViewProjection.
+func (cam *Camera) FitOrtho(b AABB3) {
+	vb := b.Transform(cam.ModelView)
+	c := vb.Center()
+	s := vb.Size()
+
+	cam.Position = cam.Position.Add(cam.ModelViewInverse.M33().MultV3(V3{c.X, c.Y, 0}))
+	cam.OrthoHeight = math.Max(s.Y, s.X*cam.Height/cam.Width)
+
+	cam.SetupModelView()
+	cam.SetupViewProjection()
 }
 
 func (cam *Camera) SetupModelView() {
@@ -43,15 +110,37 @@ func (cam *Camera) SetupModelView() {
 	cam.ModelViewInverse = cam.ModelViewInverse.Mult(RotateAxisM33(V3{1.0, 0.0, 0.0}, cam.RotAxis.X).M44())
 
 	cam.ModelView = cam.ModelViewInverse.Inverse()
+	cam.ModelViewProjection = cam.Projection.Mult(cam.ModelView)
 }
 
+// FrustumPlanes returns the world space clipping planes of the camera.
+// Call SetupViewProjection and SetupModelView first.
+func (cam *Camera) FrustumPlanes() FrustumPlanes {
+	f := cam.ModelViewProjection.FrustumPlanes()
+	if cam.ReverseZ {
+		// depth is 0 to 1 and backwards, so the near and far planes
+		// come out differently
+		m := cam.ModelViewProjection
+		f[4] = Plane{V3{m[3] - m[2], m[7] - m[6], m[11] - m[10]}, m[15] - m[14]}.Normalize()
+		f[5] = Plane{V3{m[2], m[6], m[10]}, m[14]}.Normalize()
+	}
+	return f
+}
+
+// Unproject returns the points on the near and far planes under pixel ix, iy.
+// With InfiniteFar, the second point is at distance Far (or twice Near if Far
+// isn't set) instead.
 func (cam *Camera) Unproject(ix, iy float64) [2]V3 {
 	near := V4{
 		2.0*ix/cam.Width - 1.0,
 		2.0*(cam.Height-iy)/cam.Height - 1.0,
-		-1, 1}
+		cam.depth(cam.Near), 1}
 
-	far := V4{near.X, near.Y, 1, 1}
+	distance := cam.Far
+	if cam.InfiniteFar && distance <= cam.Near {
+		distance = cam.Near * 2
+	}
+	far := V4{near.X, near.Y, cam.depth(distance), 1}
 
 	modelview := cam.ModelView
 	projection := cam.Projection
@@ -64,19 +153,73 @@ func (cam *Camera) Unproject(ix, iy float64) [2]V3 {
 		m.MultV4(far).HomogeneousToCartesian()}
 }
 
+// ScreenPoint is where Project put something on the screen.
+type ScreenPoint struct {
+	// Pixel coordinates, with 0, 0 at the top left like Unproject.
+	// Meaningless if Behind is set.
+	X, Y float64
+
+	// Depth is the normalized device depth, -1 at the near plane to 1 at
+	// the far plane (or 1 to 0 with ReverseZ).
+	Depth float64
+
+	// Behind is set if the point is behind the camera.
+	Behind bool
+
+	// Outside is set if the point is anywhere outside the view volume,
+	// including in front of the near plane or past the far plane.
+	Outside bool
+}
+
+// Project finds where a point in the world ends up on the screen.
+// Call SetupViewProjection and SetupModelView first.
+func (cam *Camera) Project(p V3) ScreenPoint {
+	c := cam.ModelViewProjection.MultV4(p.CartesianToHomogeneous())
+
+	var s ScreenPoint
+	s.Behind = cam.ModelView.MultV3(p).Z > 0
+
+	zmin := -c.W
+	if cam.ReverseZ {
+		zmin = 0
+	}
+	s.Outside = s.Behind ||
+		c.X < -c.W || c.X > c.W ||
+		c.Y < -c.W || c.Y > c.W ||
+		c.Z < zmin || c.Z > c.W
+
+	ndc := c.HomogeneousToCartesian()
+	s.X = (ndc.X + 1) * cam.Width / 2
+	s.Y = cam.Height - (ndc.Y+1)*cam.Height/2
+	s.Depth = ndc.Z
+	return s
+}
+
+// ProjectAll projects all of the points, appending the results to dst.
+func (cam *Camera) ProjectAll(dst []ScreenPoint, points []V3) []ScreenPoint {
+	for _, p := range points {
+		dst = append(dst, cam.Project(p))
+	}
+	return dst
+}
+
+// depth returns the clip space depth of something distance in front of the
+// camera.
+func (cam *Camera) depth(distance float64) float64 {
+	return cam.Projection.MultV4(V4{0, 0, -distance, 1}).HomogeneousToCartesian().Z
+}
+
+// Ortho returns an orthographic projection matrix, like glOrtho.  The
+// translation is in m[12], m[13] and m[14], like every other M44.
 func Ortho(left, right, bottom, top, near, far float64) M44 {
 	tx := (right + left) / (right - left)
 	ty := (top + bottom) / (top - bottom)
 	tz := (far + near) / (far - near)
 
 	return M44{
-		2 / (right - left), 0, 0, -tx,
-		0, 2 / (top - bottom), 0, -ty,
-		0, 0, -2 / (far - near), -tz,
-		0, 0, 0, 1,
-		//		2 / (right - left), 0, 0, 0,
-		//		0, 2 / (top - bottom), 0, 0,
-		//		0, 0, -2 / (far - near), 0,
-		//		-tx, -ty, -tz, 1,
+		2 / (right - left), 0, 0, 0,
+		0, 2 / (top - bottom), 0, 0,
+		0, 0, -2 / (far - near), 0,
+		-tx, -ty, -tz, 1,
 	}
 }
@@ -0,0 +1,177 @@
+package vector
+
+import "math"
+
+// Closest point and distance queries, mostly following Ericson's
+// Real-Time Collision Detection.
+
+func clamp(v, min, max float64) float64 {
+	if v < min {
+		return min
+	}
+	if v > max {
+		return max
+	}
+	return v
+}
+
+// ClosestPoint returns the point on the segment nearest to p, and how far
+// along the segment it is from 0 at Start to 1 at End.
+func (l Line) ClosestPoint(p V3) (V3, float64) {
+	d := l.End.Sub(l.Start)
+	dd := d.LenSq()
+	if dd == 0 {
+		return l.Start, 0
+	}
+	t := clamp(p.Sub(l.Start).Dot(d)/dd, 0, 1)
+	return l.Lerp(t), t
+}
+
+// Dist returns the distance from p to the nearest point on the segment.
+func (l Line) Dist(p V3) float64 {
+	c, _ := l.ClosestPoint(p)
+	return c.Dist(p)
+}
+
+// closestParams finds s in [0, maxS] and t in [0, 1] minimising the
+// distance between p1 + s*d1 and p2 + t*d2.
+func closestParams(p1, d1, p2, d2 V3, maxS float64) (s, t float64) {
+	r := p1.Sub(p2)
+	a := d1.LenSq()
+	e := d2.LenSq()
+	f := d2.Dot(r)
+
+	if a == 0 && e == 0 {
+		return 0, 0
+	}
+	if a == 0 {
+		return 0, clamp(f/e, 0, 1)
+	}
+
+	c := d1.Dot(r)
+	if e == 0 {
+		return clamp(-c/a, 0, maxS), 0
+	}
+
+	b := d1.Dot(d2)
+	denom := a*e - b*b
+
+	// parallel lines have a whole range of answers, so just start at 0
+	if denom != 0 {
+		s = clamp((b*f-c*e)/denom, 0, maxS)
+	}
+
+	t = (b*s + f) / e
+	if t < 0 {
+		t = 0
+		s = clamp(-c/a, 0, maxS)
+	} else if t > 1 {
+		t = 1
+		s = clamp((b-c)/a, 0, maxS)
+	}
+	return s, t
+}
+
+// ClosestPoints returns the closest pair of points between two segments,
+// the first on l and the second on o.
+func (l Line) ClosestPoints(o Line) (V3, V3) {
+	s, t := closestParams(l.Start, l.End.Sub(l.Start), o.Start, o.End.Sub(o.Start), 1)
+	return l.Lerp(s), o.Lerp(t)
+}
+
+// DistLine returns the shortest distance between two segments.
+func (l Line) DistLine(o Line) float64 {
+	a, b := l.ClosestPoints(o)
+	return a.Dist(b)
+}
+
+// ClosestPoints returns the closest pair of points between the ray and a
+// segment, the first on r and the second on l.
+func (r Ray) ClosestPoints(l Line) (V3, V3) {
+	s, t := closestParams(r.Origin, r.Direction, l.Start, l.End.Sub(l.Start), math.Inf(1))
+	return r.At(s), l.Lerp(t)
+}
+
+// DistLine returns the shortest distance between the ray and a segment.
+func (r Ray) DistLine(l Line) float64 {
+	a, b := r.ClosestPoints(l)
+	return a.Dist(b)
+}
+
+// ClosestPoint returns the point on (or in) the triangle nearest to p, and
+// its barycentric coordinate weighting A, B and C.
+func (tri Triangle) ClosestPoint(p V3) (V3, V3) {
+	a, b, c := tri.A, tri.B, tri.C
+	ab := b.Sub(a)
+	ac := c.Sub(a)
+
+	// Work out which of the seven Voronoi regions p is in: the three
+	// corners, the three edges, or the face.
+	ap := p.Sub(a)
+	d1 := ab.Dot(ap)
+	d2 := ac.Dot(ap)
+	if d1 <= 0 && d2 <= 0 {
+		return a, V3{1, 0, 0}
+	}
+
+	bp := p.Sub(b)
+	d3 := ab.Dot(bp)
+	d4 := ac.Dot(bp)
+	if d3 >= 0 && d4 <= d3 {
+		return b, V3{0, 1, 0}
+	}
+
+	vc := d1*d4 - d3*d2
+	if vc <= 0 && d1 >= 0 && d3 <= 0 {
+		v := d1 / (d1 - d3)
+		return a.Add(ab.Scale(v)), V3{1 - v, v, 0}
+	}
+
+	cp := p.Sub(c)
+	d5 := ab.Dot(cp)
+	d6 := ac.Dot(cp)
+	if d6 >= 0 && d5 <= d6 {
+		return c, V3{0, 0, 1}
+	}
+
+	vb := d5*d2 - d1*d6
+	if vb <= 0 && d2 >= 0 && d6 <= 0 {
+		w := d2 / (d2 - d6)
+		return a.Add(ac.Scale(w)), V3{1 - w, 0, w}
+	}
+
+	va := d3*d6 - d5*d4
+	if va <= 0 && d4-d3 >= 0 && d5-d6 >= 0 {
+		w := (d4 - d3) / ((d4 - d3) + (d5 - d6))
+		return b.Add(c.Sub(b).Scale(w)), V3{0, 1 - w, w}
+	}
+
+	denom := 1 / (va + vb + vc)
+	v := vb * denom
+	w := vc * denom
+	return a.Add(ab.Scale(v)).Add(ac.Scale(w)), V3{1 - v - w, v, w}
+}
+
+// Dist returns the distance from p to the nearest point of the triangle.
+func (tri Triangle) Dist(p V3) float64 {
+	c, _ := tri.ClosestPoint(p)
+	return c.Dist(p)
+}
+
+// Dist returns the distance from p to the box, which is 0 if p is inside.
+func (b AABB3) Dist(p V3) float64 {
+	return b.ClosestPoint(p).Dist(p)
+}
+
+// ClosestPoint returns the point in the box nearest to p.
+// If p is inside the box, that's just p.
+func (b OBB) ClosestPoint(p V3) V3 {
+	local := b.Rotate.Transpose().MultV3(p.Sub(b.Center))
+	local = local.Max(b.HalfSize.Scale(-1)).Min(b.HalfSize)
+	return b.Rotate.MultV3(local).Add(b.Center)
+}
+
+// Dist returns the distance from p to the box, which is 0 if p is inside.
+func (b OBB) Dist(p V3) float64 {
+	return b.ClosestPoint(p).Dist(p)
+}
@@ -1,14 +1,175 @@
 package vector
 
+import (
+	"fmt"
+	"image/color"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// RGB is a color with components from 0 to 1.  Unless a method says
+// otherwise, the components are assumed to be sRGB encoded (ie. what you'd
+// pick in a paint program) rather than linear.
 type RGB struct {
 	R float64
 	G float64
 	B float64
 }
 
+// RGBA is an RGB color with straight (not premultiplied) alpha.
 type RGBA struct {
 	R float64
 	G float64
 	B float64
 	A float64
 }
+
+func (c RGBA) RGB() RGB {
+	return RGB{c.R, c.G, c.B}
+}
+
+// Alpha returns the color with an alpha channel.
+func (c RGB) Alpha(a float64) RGBA {
+	return RGBA{c.R, c.G, c.B, a}
+}
+
+// unit clamps to 0..1 and scales to 0..max, rounding.
+func unit(v float64, max float64) uint32 {
+	if v <= 0 || v != v {
+		return 0
+	}
+	if v >= 1 {
+		return uint32(max)
+	}
+	return uint32(v*max + 0.5)
+}
+
+// RGBA implements image/color.Color.
+func (c RGB) RGBA() (r, g, b, a uint32) {
+	return unit(c.R, 0xffff), unit(c.G, 0xffff), unit(c.B, 0xffff), 0xffff
+}
+
+// RGBA implements image/color.Color.
+func (c RGBA) RGBA() (r, g, b, a uint32) {
+	a = unit(c.A, 0xffff)
+	af := float64(a) / 0xffff
+	return unit(c.R*af, 0xffff), unit(c.G*af, 0xffff), unit(c.B*af, 0xffff), a
+}
+
+// ColorRGBA converts any image/color.Color.
+func ColorRGBA(c color.Color) RGBA {
+	r, g, b, a := c.RGBA()
+	if a == 0 {
+		return RGBA{}
+	}
+	fa := float64(a)
+	return RGBA{float64(r) / fa, float64(g) / fa, float64(b) / fa, fa / 0xffff}
+}
+
+// Uint32 packs the color as 0xRRGGBB.
+func (c RGB) Uint32() uint32 {
+	return unit(c.R, 0xff)<<16 | unit(c.G, 0xff)<<8 | unit(c.B, 0xff)
+}
+
+// Uint32RGB unpacks a color from 0xRRGGBB.
+func Uint32RGB(u uint32) RGB {
+	return RGB{
+		float64(u>>16&0xff) / 0xff,
+		float64(u>>8&0xff) / 0xff,
+		float64(u&0xff) / 0xff}
+}
+
+// Uint32 packs the color as 0xRRGGBBAA.
+func (c RGBA) Uint32() uint32 {
+	return c.RGB().Uint32()<<8 | unit(c.A, 0xff)
+}
+
+// Uint32RGBA unpacks a color from 0xRRGGBBAA.
+func Uint32RGBA(u uint32) RGBA {
+	return Uint32RGB(u >> 8).Alpha(float64(u&0xff) / 0xff)
+}
+
+// Hex returns the color like "#ff8000".
+func (c RGB) Hex() string {
+	return fmt.Sprintf("#%06x", c.Uint32())
+}
+
+// Hex returns the color like "#ff8000ff".
+func (c RGBA) Hex() string {
+	return fmt.Sprintf("#%08x", c.Uint32())
+}
+
+// HexRGBA parses a CSS style hex color: #rgb, #rgba, #rrggbb or #rrggbbaa.
+// The # is optional.  Alpha is 1 if it isn't given.
+func HexRGBA(s string) (RGBA, error) {
+	h := strings.TrimPrefix(s, "#")
+	if len(h) == 3 || len(h) == 4 {
+		var b strings.Builder
+		for _, r := range h {
+			b.WriteRune(r)
+			b.WriteRune(r)
+		}
+		h = b.String()
+	}
+	if len(h) == 6 {
+		h += "ff"
+	}
+	if len(h) != 8 {
+		return RGBA{}, fmt.Errorf("invalid hex color %q", s)
+	}
+	u, err := strconv.ParseUint(h, 16, 32)
+	if err != nil {
+		return RGBA{}, fmt.Errorf("invalid hex color %q", s)
+	}
+	return Uint32RGBA(uint32(u)), nil
+}
+
+// HexRGB parses a hex color like HexRGBA, throwing away any alpha.
+func HexRGB(s string) (RGB, error) {
+	c, err := HexRGBA(s)
+	return c.RGB(), err
+}
+
+func (c RGB) String() string {
+	return fmt.Sprintf("rgb(%.4f, %.4f, %.4f)", c.R, c.G, c.B)
+}
+
+func (c RGBA) String() string {
+	return fmt.Sprintf("rgba(%.4f, %.4f, %.4f, %.4f)", c.R, c.G, c.B, c.A)
+}
+
+// sRGB transfer functions
+func linear(v float64) float64 {
+	if v <= 0.04045 {
+		return v / 12.92
+	}
+	return math.Pow((v+0.055)/1.055, 2.4)
+}
+func encode(v float64) float64 {
+	if v <= 0.0031308 {
+		return v * 12.92
+	}
+	return 1.055*math.Pow(v, 1/2.4) - 0.055
+}
+
+// Linear decodes an sRGB color into linear light, which is what you want
+// for lighting and blending maths.
+func (c RGB) Linear() RGB {
+	return RGB{linear(c.R), linear(c.G), linear(c.B)}
+}
+
+// SRGB encodes a linear color back into sRGB.
+func (c RGB) SRGB() RGB {
+	return RGB{encode(c.R), encode(c.G), encode(c.B)}
+}
+
+// Linear decodes the color channels, leaving alpha alone.
+func (c RGBA) Linear() RGBA {
+	return c.RGB().Linear().Alpha(c.A)
+}
+
+// SRGB encodes the color channels, leaving alpha alone.
+func (c RGBA) SRGB() RGBA {
+	return c.RGB().SRGB().Alpha(c.A)
+}
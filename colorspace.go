@@ -0,0 +1,217 @@
+package vector
+
+import "math"
+
+// HSV is hue, saturation and value.  S and V go from 0 to 1.
+type HSV struct {
+	H    Degree
+	S, V float64
+}
+
+// HSL is hue, saturation and lightness.  S and L go from 0 to 1.
+type HSL struct {
+	H    Degree
+	S, L float64
+}
+
+// CIEXYZ is a CIE 1931 XYZ color, relative to the D65 white point with
+// Y = 1 for white.
+type CIEXYZ struct {
+	X, Y, Z float64
+}
+
+// Lab is a CIE L*a*b* color (D65).  L goes from 0 to 100.
+type Lab struct {
+	L, A, B float64
+}
+
+// OKLab is Björn Ottosson's perceptual color space.  L goes from 0 to 1.
+// Lerping in OKLab gives much nicer gradients than in RGB.
+type OKLab struct {
+	L, A, B float64
+}
+
+// hue works out the hue (in degrees) shared by HSV and HSL.
+func (c RGB) hue(max, delta float64) Degree {
+	if delta == 0 {
+		return 0
+	}
+	var h float64
+	switch max {
+	case c.R:
+		h = math.Mod((c.G-c.B)/delta, 6)
+	case c.G:
+		h = (c.B-c.R)/delta + 2
+	default:
+		h = (c.R-c.G)/delta + 4
+	}
+	h *= 60
+	if h < 0 {
+		h += 360
+	}
+	return Degree(h)
+}
+
+// hueRGB is the shared end of HSV and HSL back to RGB, given the chroma and
+// the amount to add to every channel.
+func hueRGB(h Degree, chroma, m float64) RGB {
+	hh := math.Mod(float64(h), 360)
+	if hh < 0 {
+		hh += 360
+	}
+	hh /= 60
+	x := chroma * (1 - math.Abs(math.Mod(hh, 2)-1))
+
+	var c RGB
+	switch {
+	case hh < 1:
+		c = RGB{chroma, x, 0}
+	case hh < 2:
+		c = RGB{x, chroma, 0}
+	case hh < 3:
+		c = RGB{0, chroma, x}
+	case hh < 4:
+		c = RGB{0, x, chroma}
+	case hh < 5:
+		c = RGB{x, 0, chroma}
+	default:
+		c = RGB{chroma, 0, x}
+	}
+	return RGB{c.R + m, c.G + m, c.B + m}
+}
+
+func (c RGB) HSV() HSV {
+	max := math.Max(c.R, math.Max(c.G, c.B))
+	min := math.Min(c.R, math.Min(c.G, c.B))
+	delta := max - min
+
+	var s float64
+	if max != 0 {
+		s = delta / max
+	}
+	return HSV{c.hue(max, delta), s, max}
+}
+
+func (c HSV) RGB() RGB {
+	chroma := c.V * c.S
+	return hueRGB(c.H, chroma, c.V-chroma)
+}
+
+func (c RGB) HSL() HSL {
+	max := math.Max(c.R, math.Max(c.G, c.B))
+	min := math.Min(c.R, math.Min(c.G, c.B))
+	delta := max - min
+	l := (max + min) / 2
+
+	var s float64
+	if delta != 0 {
+		s = delta / (1 - math.Abs(2*l-1))
+	}
+	return HSL{c.hue(max, delta), s, l}
+}
+
+func (c HSL) RGB() RGB {
+	chroma := (1 - math.Abs(2*c.L-1)) * c.S
+	return hueRGB(c.H, chroma, c.L-chroma/2)
+}
+
+// Linear sRGB to XYZ.  The published inverses are only good to the 7 or so
+// digits they're printed with, so the ones going back are worked out from
+// the forward matrices, which makes round trips exact to rounding.
+var (
+	linearToXYZ = RowsM33(
+		V3{0.4124564, 0.3575761, 0.1804375},
+		V3{0.2126729, 0.7151522, 0.0721750},
+		V3{0.0193339, 0.1191920, 0.9503041})
+	xyzToLinear = linearToXYZ.Inverse()
+)
+
+// CIEXYZ converts an sRGB color to CIE XYZ.
+func (c RGB) CIEXYZ() CIEXYZ {
+	l := c.Linear()
+	v := linearToXYZ.MultV3(V3{l.R, l.G, l.B})
+	return CIEXYZ{v.X, v.Y, v.Z}
+}
+
+// RGB converts to sRGB.  Colors outside the sRGB gamut come out with
+// components below 0 or above 1.
+func (c CIEXYZ) RGB() RGB {
+	v := xyzToLinear.MultV3(V3{c.X, c.Y, c.Z})
+	return RGB{v.X, v.Y, v.Z}.SRGB()
+}
+
+// D65 reference white
+var whiteXYZ = CIEXYZ{0.95047, 1.0, 1.08883}
+
+const labδ = 6.0 / 29.0
+
+func labf(t float64) float64 {
+	if t > labδ*labδ*labδ {
+		return math.Cbrt(t)
+	}
+	return t/(3*labδ*labδ) + 4.0/29.0
+}
+
+func labfinv(t float64) float64 {
+	if t > labδ {
+		return t * t * t
+	}
+	return 3 * labδ * labδ * (t - 4.0/29.0)
+}
+
+func (c CIEXYZ) Lab() Lab {
+	fx := labf(c.X / whiteXYZ.X)
+	fy := labf(c.Y / whiteXYZ.Y)
+	fz := labf(c.Z / whiteXYZ.Z)
+	return Lab{116*fy - 16, 500 * (fx - fy), 200 * (fy - fz)}
+}
+
+func (c Lab) CIEXYZ() CIEXYZ {
+	fy := (c.L + 16) / 116
+	fx := fy + c.A/500
+	fz := fy - c.B/200
+	return CIEXYZ{
+		whiteXYZ.X * labfinv(fx),
+		whiteXYZ.Y * labfinv(fy),
+		whiteXYZ.Z * labfinv(fz)}
+}
+
+// Lab converts an sRGB color to CIE L*a*b*.
+func (c RGB) Lab() Lab {
+	return c.CIEXYZ().Lab()
+}
+
+func (c Lab) RGB() RGB {
+	return c.CIEXYZ().RGB()
+}
+
+// OKLab's two matrices: linear sRGB to LMS cone responses, and the cube
+// roots of those to L, a, b.  The inverses are worked out like xyzToLinear.
+var (
+	linearToLMS = RowsM33(
+		V3{0.4122214708, 0.5363325363, 0.0514459929},
+		V3{0.2119034982, 0.6806995451, 0.1073969566},
+		V3{0.0883024619, 0.2817188376, 0.6299787005})
+	lmsToOKLab = RowsM33(
+		V3{0.2104542553, 0.7936177850, -0.0040720468},
+		V3{1.9779984951, -2.4285922050, 0.4505937099},
+		V3{0.0259040371, 0.7827717662, -0.8086757660})
+	lmsToLinear = linearToLMS.Inverse()
+	okLabToLMS  = lmsToOKLab.Inverse()
+)
+
+// OKLab converts an sRGB color to OKLab.
+func (c RGB) OKLab() OKLab {
+	l := c.Linear()
+	lms := linearToLMS.MultV3(V3{l.R, l.G, l.B})
+	lms = V3{math.Cbrt(lms.X), math.Cbrt(lms.Y), math.Cbrt(lms.Z)}
+	v := lmsToOKLab.MultV3(lms)
+	return OKLab{v.X, v.Y, v.Z}
+}
+
+func (c OKLab) RGB() RGB {
+	lms := okLabToLMS.MultV3(V3{c.L, c.A, c.B})
+	lms = V3{lms.X * lms.X * lms.X, lms.Y * lms.Y * lms.Y, lms.Z * lms.Z * lms.Z}
+	v := lmsToLinear.MultV3(lms)
+	return RGB{v.X, v.Y, v.Z}.SRGB()
+}
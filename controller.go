@@ -0,0 +1,144 @@
+package vector
+
+import "math"
+
+// The camera controllers below all assume Y is up in the world, and keep
+// pitch just short of straight up or down so the view never flips over.
+// Feed them input deltas, then call Update to copy the result into a Camera.
+
+const maxPitch = τ/4 - 0.001
+
+func clampPitch(p Radian) Radian {
+	return Radian(math.Max(-maxPitch, math.Min(maxPitch, float64(p))))
+}
+
+// OrbitController swings a camera around a pivot point, always looking at it.
+type OrbitController struct {
+	Pivot    V3
+	Distance float64
+
+	// Yaw turns about the world Y axis and Pitch tilts up and down.
+	// With both zero the camera sits on the +Z side of the pivot.
+	Yaw   Radian
+	Pitch Radian
+
+	// If non-zero, Zoom won't go past these.
+	MinDistance float64
+	MaxDistance float64
+}
+
+// Rotate orbits around the pivot.
+func (o *OrbitController) Rotate(yaw, pitch Radian) {
+	o.Yaw += yaw
+	o.Pitch = clampPitch(o.Pitch + pitch)
+}
+
+// Zoom multiplies the distance from the pivot, so 0.5 gets twice as close.
+func (o *OrbitController) Zoom(factor float64) {
+	o.Distance *= factor
+	if o.MinDistance != 0 && o.Distance < o.MinDistance {
+		o.Distance = o.MinDistance
+	}
+	if o.MaxDistance != 0 && o.Distance > o.MaxDistance {
+		o.Distance = o.MaxDistance
+	}
+}
+
+// Pan slides the pivot (and so the camera) sideways and up in view space.
+func (o *OrbitController) Pan(right, up float64) {
+	r := o.Euler().M33()
+	o.Pivot = o.Pivot.
+		Add(r.MultV3(V3{1, 0, 0}).Scale(right)).
+		Add(r.MultV3(V3{0, 1, 0}).Scale(up))
+}
+
+// Euler returns the camera rotation.
+func (o *OrbitController) Euler() Euler {
+	return Euler{o.Pitch, o.Yaw, 0}
+}
+
+// Position returns where the camera ends up.
+func (o *OrbitController) Position() V3 {
+	return o.Pivot.Add(o.Euler().M33().MultV3(V3{0, 0, o.Distance}))
+}
+
+// Update moves the camera and regenerates its modelview matrices.
+func (o *OrbitController) Update(cam *Camera) {
+	cam.Position = o.Position()
+	cam.RotAxis = o.Euler()
+	cam.SetupModelView()
+}
+
+// FirstPersonController walks around on the ground, looking about with
+// yaw and pitch.
+type FirstPersonController struct {
+	Position V3
+	Yaw      Radian
+	Pitch    Radian
+}
+
+// Look turns the head.  Pitch is clamped so you can't look past straight
+// up or down.
+func (f *FirstPersonController) Look(yaw, pitch Radian) {
+	f.Yaw += yaw
+	f.Pitch = clampPitch(f.Pitch + pitch)
+}
+
+// Move walks relative to the way we're facing, ignoring pitch so looking
+// down doesn't slow you down.  up moves along world Y.
+func (f *FirstPersonController) Move(forward, right, up float64) {
+	r := RotateAxisM33(V3{0, 1, 0}, f.Yaw)
+	f.Position = f.Position.
+		Add(r.MultV3(V3{0, 0, -1}).Scale(forward)).
+		Add(r.MultV3(V3{1, 0, 0}).Scale(right)).
+		Add(V3{0, up, 0})
+}
+
+// Euler returns the camera rotation.
+func (f *FirstPersonController) Euler() Euler {
+	return Euler{f.Pitch, f.Yaw, 0}
+}
+
+// Update moves the camera and regenerates its modelview matrices.
+func (f *FirstPersonController) Update(cam *Camera) {
+	cam.Position = f.Position
+	cam.RotAxis = f.Euler()
+	cam.SetupModelView()
+}
+
+// FreeFlyController flies anywhere with no notion of up, like a
+// spaceship.  The zero value has a zero Orientation, which is treated as
+// the identity.
+type FreeFlyController struct {
+	Position    V3
+	Orientation Q
+}
+
+func (f *FreeFlyController) orientation() Q {
+	if f.Orientation == (Q{}) {
+		return IdentityQ()
+	}
+	return f.Orientation
+}
+
+// Rotate turns relative to the current orientation: yaw about our own up,
+// pitch about our own right, and roll about the view direction.
+func (f *FreeFlyController) Rotate(yaw, pitch, roll Radian) {
+	f.Orientation = f.orientation().
+		Mult(AxisAngleQ(V3{0, 1, 0}, yaw)).
+		Mult(AxisAngleQ(V3{1, 0, 0}, pitch)).
+		Mult(AxisAngleQ(V3{0, 0, -1}, roll)).
+		Normalize()
+}
+
+// Move flies relative to the current orientation.
+func (f *FreeFlyController) Move(forward, right, up float64) {
+	f.Position = f.Position.Add(f.orientation().M33().MultV3(V3{right, up, -forward}))
+}
+
+// Update moves the camera and regenerates its modelview matrices.
+func (f *FreeFlyController) Update(cam *Camera) {
+	cam.Position = f.Position
+	cam.RotAxis = f.orientation().M33().Euler()
+	cam.SetupModelView()
+}
@@ -0,0 +1,122 @@
+package vector
+
+import "github.com/yobert/vector/vector32"
+
+// Conversions to and from the float32 types in package vector32.
+
+func (v V2) Float32() vector32.V2 {
+	return vector32.V2{X: float32(v.X), Y: float32(v.Y)}
+}
+func V2FromFloat32(v vector32.V2) V2 {
+	return V2{float64(v.X), float64(v.Y)}
+}
+
+func (v V3) Float32() vector32.V3 {
+	return vector32.V3{X: float32(v.X), Y: float32(v.Y), Z: float32(v.Z)}
+}
+func V3FromFloat32(v vector32.V3) V3 {
+	return V3{float64(v.X), float64(v.Y), float64(v.Z)}
+}
+
+func (v V4) Float32() vector32.V4 {
+	return vector32.V4{X: float32(v.X), Y: float32(v.Y), Z: float32(v.Z), W: float32(v.W)}
+}
+func V4FromFloat32(v vector32.V4) V4 {
+	return V4{float64(v.X), float64(v.Y), float64(v.Z), float64(v.W)}
+}
+
+func (q Q) Float32() vector32.Q {
+	return vector32.Q{R: float32(q.R), I: float32(q.I), J: float32(q.J), K: float32(q.K)}
+}
+func QFromFloat32(q vector32.Q) Q {
+	return Q{float64(q.R), float64(q.I), float64(q.J), float64(q.K)}
+}
+
+func (e Euler) Float32() vector32.Euler {
+	return vector32.Euler{X: vector32.Radian(e.X), Y: vector32.Radian(e.Y), Z: vector32.Radian(e.Z)}
+}
+func EulerFromFloat32(e vector32.Euler) Euler {
+	return Euler{Radian(e.X), Radian(e.Y), Radian(e.Z)}
+}
+
+func (m M23) Float32() (o vector32.M23) {
+	for i, v := range m {
+		o[i] = float32(v)
+	}
+	return
+}
+func M23FromFloat32(m vector32.M23) (o M23) {
+	for i, v := range m {
+		o[i] = float64(v)
+	}
+	return
+}
+
+func (m M33) Float32() (o vector32.M33) {
+	for i, v := range m {
+		o[i] = float32(v)
+	}
+	return
+}
+func M33FromFloat32(m vector32.M33) (o M33) {
+	for i, v := range m {
+		o[i] = float64(v)
+	}
+	return
+}
+
+func (m M34) Float32() (o vector32.M34) {
+	for i, v := range m {
+		o[i] = float32(v)
+	}
+	return
+}
+func M34FromFloat32(m vector32.M34) (o M34) {
+	for i, v := range m {
+		o[i] = float64(v)
+	}
+	return
+}
+
+func (m M44) Float32() (o vector32.M44) {
+	for i, v := range m {
+		o[i] = float32(v)
+	}
+	return
+}
+func M44FromFloat32(m vector32.M44) (o M44) {
+	for i, v := range m {
+		o[i] = float64(v)
+	}
+	return
+}
+
+func (c RGB) Float32() vector32.RGB {
+	return vector32.RGB{R: float32(c.R), G: float32(c.G), B: float32(c.B)}
+}
+func RGBFromFloat32(c vector32.RGB) RGB {
+	return RGB{float64(c.R), float64(c.G), float64(c.B)}
+}
+
+func (c RGBA) Float32() vector32.RGBA {
+	return vector32.RGBA{R: float32(c.R), G: float32(c.G), B: float32(c.B), A: float32(c.A)}
+}
+func RGBAFromFloat32(c vector32.RGBA) RGBA {
+	return RGBA{float64(c.R), float64(c.G), float64(c.B), float64(c.A)}
+}
+
+// V3sFloat32 converts a whole slice at once, for vertex buffers and such.
+func V3sFloat32(vs []V3) []vector32.V3 {
+	o := make([]vector32.V3, len(vs))
+	for i, v := range vs {
+		o[i] = v.Float32()
+	}
+	return o
+}
+func V3sFromFloat32(vs []vector32.V3) []V3 {
+	o := make([]V3, len(vs))
+	for i, v := range vs {
+		o[i] = V3FromFloat32(v)
+	}
+	return o
+}
@@ -1,2 +1,7 @@
 // Package vector implements matrix, quaternion, and vector maths.
+//
+// Package vector32 has the same types using float32, and is generated from
+// this package.
 package vector
+
+//go:generate go run gen32.go
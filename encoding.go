@@ -0,0 +1,209 @@
+package vector
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// All of the types can be marshalled losslessly as JSON (an array of
+// numbers), text (numbers separated by spaces) and binary (each component
+// as a little endian IEEE 754 float).  Components are always in field
+// order, and matrices are in the order they're stored in.  A JSON null
+// leaves the value unchanged, as encoding/json does.
+
+// floatSize is the size in bytes of one component.
+var floatSize = binary.Size(float64(0))
+
+func marshalBinary(fs ...float64) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.Grow(len(fs) * floatSize)
+	if err := binary.Write(&buf, binary.LittleEndian, fs); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func unmarshalBinary(b []byte, fs ...*float64) error {
+	if len(b) != len(fs)*floatSize {
+		return fmt.Errorf("vector: binary data is %d bytes, want %d", len(b), len(fs)*floatSize)
+	}
+	vals := make([]float64, len(fs))
+	if err := binary.Read(bytes.NewReader(b), binary.LittleEndian, vals); err != nil {
+		return err
+	}
+	for i, f := range fs {
+		*f = vals[i]
+	}
+	return nil
+}
+
+func marshalText(fs ...float64) ([]byte, error) {
+	var b []byte
+	for i, f := range fs {
+		if i > 0 {
+			b = append(b, ' ')
+		}
+		// %v is the shortest representation that parses back exactly
+		b = fmt.Append(b, f)
+	}
+	return b, nil
+}
+
+func unmarshalText(b []byte, fs ...*float64) error {
+	fields := strings.Fields(string(b))
+	if len(fields) != len(fs) {
+		return fmt.Errorf("vector: text has %d numbers, want %d", len(fields), len(fs))
+	}
+	for i, s := range fields {
+		f, err := strconv.ParseFloat(s, floatSize*8)
+		if err != nil {
+			return err
+		}
+		*fs[i] = float64(f)
+	}
+	return nil
+}
+
+func marshalJSON(fs ...float64) ([]byte, error) {
+	return json.Marshal(fs)
+}
+
+func unmarshalJSON(b []byte, fs ...*float64) error {
+	// like encoding/json, null leaves the value alone
+	if bytes.Equal(bytes.TrimSpace(b), []byte("null")) {
+		return nil
+	}
+
+	var vals []float64
+	if err := json.Unmarshal(b, &vals); err != nil {
+		return err
+	}
+	if len(vals) != len(fs) {
+		return fmt.Errorf("vector: JSON array has %d numbers, want %d", len(vals), len(fs))
+	}
+	for i, f := range fs {
+		*f = vals[i]
+	}
+	return nil
+}
+
+func ptrs(fs []float64) []*float64 {
+	p := make([]*float64, len(fs))
+	for i := range fs {
+		p[i] = &fs[i]
+	}
+	return p
+}
+
+func (v V2) MarshalBinary() ([]byte, error)    { return marshalBinary(v.X, v.Y) }
+func (v *V2) UnmarshalBinary(b []byte) error   { return unmarshalBinary(b, &v.X, &v.Y) }
+func (v V2) MarshalText() ([]byte, error)      { return marshalText(v.X, v.Y) }
+func (v *V2) UnmarshalText(b []byte) error     { return unmarshalText(b, &v.X, &v.Y) }
+func (v V2) MarshalJSON() ([]byte, error)      { return marshalJSON(v.X, v.Y) }
+func (v *V2) UnmarshalJSON(b []byte) error     { return unmarshalJSON(b, &v.X, &v.Y) }
+func (v V3) MarshalBinary() ([]byte, error)    { return marshalBinary(v.X, v.Y, v.Z) }
+func (v *V3) UnmarshalBinary(b []byte) error   { return unmarshalBinary(b, &v.X, &v.Y, &v.Z) }
+func (v V3) MarshalText() ([]byte, error)      { return marshalText(v.X, v.Y, v.Z) }
+func (v *V3) UnmarshalText(b []byte) error     { return unmarshalText(b, &v.X, &v.Y, &v.Z) }
+func (v V3) MarshalJSON() ([]byte, error)      { return marshalJSON(v.X, v.Y, v.Z) }
+func (v *V3) UnmarshalJSON(b []byte) error     { return unmarshalJSON(b, &v.X, &v.Y, &v.Z) }
+func (v V4) MarshalBinary() ([]byte, error)    { return marshalBinary(v.X, v.Y, v.Z, v.W) }
+func (v *V4) UnmarshalBinary(b []byte) error   { return unmarshalBinary(b, &v.X, &v.Y, &v.Z, &v.W) }
+func (v V4) MarshalText() ([]byte, error)      { return marshalText(v.X, v.Y, v.Z, v.W) }
+func (v *V4) UnmarshalText(b []byte) error     { return unmarshalText(b, &v.X, &v.Y, &v.Z, &v.W) }
+func (v V4) MarshalJSON() ([]byte, error)      { return marshalJSON(v.X, v.Y, v.Z, v.W) }
+func (v *V4) UnmarshalJSON(b []byte) error     { return unmarshalJSON(b, &v.X, &v.Y, &v.Z, &v.W) }
+func (q Q) MarshalBinary() ([]byte, error)     { return marshalBinary(q.R, q.I, q.J, q.K) }
+func (q *Q) UnmarshalBinary(b []byte) error    { return unmarshalBinary(b, &q.R, &q.I, &q.J, &q.K) }
+func (q Q) MarshalText() ([]byte, error)       { return marshalText(q.R, q.I, q.J, q.K) }
+func (q *Q) UnmarshalText(b []byte) error      { return unmarshalText(b, &q.R, &q.I, &q.J, &q.K) }
+func (q Q) MarshalJSON() ([]byte, error)       { return marshalJSON(q.R, q.I, q.J, q.K) }
+func (q *Q) UnmarshalJSON(b []byte) error      { return unmarshalJSON(b, &q.R, &q.I, &q.J, &q.K) }
+func (c RGB) MarshalBinary() ([]byte, error)   { return marshalBinary(c.R, c.G, c.B) }
+func (c *RGB) UnmarshalBinary(b []byte) error  { return unmarshalBinary(b, &c.R, &c.G, &c.B) }
+func (c RGB) MarshalText() ([]byte, error)     { return marshalText(c.R, c.G, c.B) }
+func (c *RGB) UnmarshalText(b []byte) error    { return unmarshalText(b, &c.R, &c.G, &c.B) }
+func (c RGB) MarshalJSON() ([]byte, error)     { return marshalJSON(c.R, c.G, c.B) }
+func (c *RGB) UnmarshalJSON(b []byte) error    { return unmarshalJSON(b, &c.R, &c.G, &c.B) }
+func (c RGBA) MarshalBinary() ([]byte, error)  { return marshalBinary(c.R, c.G, c.B, c.A) }
+func (c *RGBA) UnmarshalBinary(b []byte) error { return unmarshalBinary(b, &c.R, &c.G, &c.B, &c.A) }
+func (c RGBA) MarshalText() ([]byte, error)    { return marshalText(c.R, c.G, c.B, c.A) }
+func (c *RGBA) UnmarshalText(b []byte) error   { return unmarshalText(b, &c.R, &c.G, &c.B, &c.A) }
+func (c RGBA) MarshalJSON() ([]byte, error)    { return marshalJSON(c.R, c.G, c.B, c.A) }
+func (c *RGBA) UnmarshalJSON(b []byte) error   { return unmarshalJSON(b, &c.R, &c.G, &c.B, &c.A) }
+func (m M23) MarshalBinary() ([]byte, error)   { return marshalBinary(m[:]...) }
+func (m *M23) UnmarshalBinary(b []byte) error  { return unmarshalBinary(b, ptrs(m[:])...) }
+func (m M23) MarshalText() ([]byte, error)     { return marshalText(m[:]...) }
+func (m *M23) UnmarshalText(b []byte) error    { return unmarshalText(b, ptrs(m[:])...) }
+func (m M23) MarshalJSON() ([]byte, error)     { return marshalJSON(m[:]...) }
+func (m *M23) UnmarshalJSON(b []byte) error    { return unmarshalJSON(b, ptrs(m[:])...) }
+func (m M33) MarshalBinary() ([]byte, error)   { return marshalBinary(m[:]...) }
+func (m *M33) UnmarshalBinary(b []byte) error  { return unmarshalBinary(b, ptrs(m[:])...) }
+func (m M33) MarshalText() ([]byte, error)     { return marshalText(m[:]...) }
+func (m *M33) UnmarshalText(b []byte) error    { return unmarshalText(b, ptrs(m[:])...) }
+func (m M33) MarshalJSON() ([]byte, error)     { return marshalJSON(m[:]...) }
+func (m *M33) UnmarshalJSON(b []byte) error    { return unmarshalJSON(b, ptrs(m[:])...) }
+func (m M34) MarshalBinary() ([]byte, error)   { return marshalBinary(m[:]...) }
+func (m *M34) UnmarshalBinary(b []byte) error  { return unmarshalBinary(b, ptrs(m[:])...) }
+func (m M34) MarshalText() ([]byte, error)     { return marshalText(m[:]...) }
+func (m *M34) UnmarshalText(b []byte) error    { return unmarshalText(b, ptrs(m[:])...) }
+func (m M34) MarshalJSON() ([]byte, error)     { return marshalJSON(m[:]...) }
+func (m *M34) UnmarshalJSON(b []byte) error    { return unmarshalJSON(b, ptrs(m[:])...) }
+func (m M44) MarshalBinary() ([]byte, error)   { return marshalBinary(m[:]...) }
+func (m *M44) UnmarshalBinary(b []byte) error  { return unmarshalBinary(b, ptrs(m[:])...) }
+func (m M44) MarshalText() ([]byte, error)     { return marshalText(m[:]...) }
+func (m *M44) UnmarshalText(b []byte) error    { return unmarshalText(b, ptrs(m[:])...) }
+func (m M44) MarshalJSON() ([]byte, error)     { return marshalJSON(m[:]...) }
+func (m *M44) UnmarshalJSON(b []byte) error    { return unmarshalJSON(b, ptrs(m[:])...) }
+
+func (e Euler) MarshalBinary() ([]byte, error) {
+	return marshalBinary(float64(e.X), float64(e.Y), float64(e.Z))
+}
+func (e *Euler) UnmarshalBinary(b []byte) error {
+	return unmarshalBinary(b, (*float64)(&e.X), (*float64)(&e.Y), (*float64)(&e.Z))
+}
+func (e Euler) MarshalText() ([]byte, error) {
+	return marshalText(float64(e.X), float64(e.Y), float64(e.Z))
+}
+func (e *Euler) UnmarshalText(b []byte) error {
+	return unmarshalText(b, (*float64)(&e.X), (*float64)(&e.Y), (*float64)(&e.Z))
+}
+func (e Euler) MarshalJSON() ([]byte, error) {
+	return marshalJSON(float64(e.X), float64(e.Y), float64(e.Z))
+}
+func (e *Euler) UnmarshalJSON(b []byte) error {
+	return unmarshalJSON(b, (*float64)(&e.X), (*float64)(&e.Y), (*float64)(&e.Z))
+}
+
+// Flat is any of the types made of nothing but floats, which can be
+// written out in bulk.
+type Flat interface {
+	V2 | V3 | V4 | Q | Euler | M23 | M33 | M34 | M44 | RGB | RGBA
+}
+
+// AppendBinary appends a whole slice to b in the same layout as
+// MarshalBinary, one after another.
+func AppendBinary[T Flat](b []byte, s []T) []byte {
+	buf := bytes.NewBuffer(b)
+	// can't fail: everything in a Flat is a fixed size
+	binary.Write(buf, binary.LittleEndian, s)
+	return buf.Bytes()
+}
+
+// DecodeBinary decodes a slice written by AppendBinary.
+func DecodeBinary[T Flat](b []byte) ([]T, error) {
+	var zero T
+	size := binary.Size(zero)
+	if len(b)%size != 0 {
+		return nil, fmt.Errorf("vector: binary data is %d bytes, not a multiple of %d", len(b), size)
+	}
+	s := make([]T, len(b)/size)
+	if err := binary.Read(bytes.NewReader(b), binary.LittleEndian, s); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
@@ -1,6 +1,11 @@
 package vector
 
+import "math"
+
 // Euler represents three amounts of rotation, about the X, Y, and Z axis.
+// They are applied in that order about the fixed world axes (so the matrix
+// is Rz * Ry * Rx), which is the same as EulerAngles{Order: XYZ, Extrinsic:
+// true}.  Use EulerAngles for any other order.
 type Euler struct {
 	X, Y, Z Radian
 }
@@ -14,9 +19,10 @@ func (e Euler) Q() Q {
 	cz := Cos(e.Z / 2)
 	sz := Sin(e.Z / 2)
 
+	// qz * qy * qx
 	return Q{
-		cx*cy*cz - sx*sy*sz,
-		sx*cy*cz + cx*sy*sz,
+		cx*cy*cz + sx*sy*sz,
+		sx*cy*cz - cx*sy*sz,
 		cx*sy*cz + sx*cy*sz,
 		cx*cy*sz - sx*sy*cz}
 }
@@ -28,3 +34,205 @@ func (e Euler) M33() M33 {
 		Mult(RotateAxisM33(V3{0, 1, 0}, e.Y)).
 		Mult(RotateAxisM33(V3{1, 0, 0}, e.X))
 }
+
+// EulerAngles converts to the general form.
+func (e Euler) EulerAngles() EulerAngles {
+	return EulerAngles{XYZ, true, e.X, e.Y, e.Z}
+}
+
+// RotationOrder is the sequence of axes a set of EulerAngles rotate about.
+// The first six are Tait–Bryan angles (all three axes) and the last six
+// are proper Euler angles (the first axis is repeated).
+type RotationOrder int
+
+const (
+	XYZ RotationOrder = iota
+	XZY
+	YXZ
+	YZX
+	ZXY
+	ZYX
+	XYX
+	XZX
+	YXY
+	YZY
+	ZXZ
+	ZYZ
+)
+
+var rotationOrders = [...]struct {
+	name string
+	axes [3]int
+}{
+	XYZ: {"XYZ", [3]int{0, 1, 2}},
+	XZY: {"XZY", [3]int{0, 2, 1}},
+	YXZ: {"YXZ", [3]int{1, 0, 2}},
+	YZX: {"YZX", [3]int{1, 2, 0}},
+	ZXY: {"ZXY", [3]int{2, 0, 1}},
+	ZYX: {"ZYX", [3]int{2, 1, 0}},
+	XYX: {"XYX", [3]int{0, 1, 0}},
+	XZX: {"XZX", [3]int{0, 2, 0}},
+	YXY: {"YXY", [3]int{1, 0, 1}},
+	YZY: {"YZY", [3]int{1, 2, 1}},
+	ZXZ: {"ZXZ", [3]int{2, 0, 2}},
+	ZYZ: {"ZYZ", [3]int{2, 1, 2}},
+}
+
+var unitAxes = [3]V3{{1, 0, 0}, {0, 1, 0}, {0, 0, 1}}
+
+func (o RotationOrder) valid() bool {
+	return o >= 0 && int(o) < len(rotationOrders)
+}
+
+func (o RotationOrder) String() string {
+	if !o.valid() {
+		return "invalid"
+	}
+	return rotationOrders[o].name
+}
+
+// Axes returns the indexes (0 for X, 1 for Y, 2 for Z) of the three axes in
+// order.  It panics if o isn't one of the orders above.
+func (o RotationOrder) Axes() [3]int {
+	if !o.valid() {
+		panic("vector: invalid RotationOrder")
+	}
+	return rotationOrders[o].axes
+}
+
+// Reverse returns the order with the axes the other way around.  An invalid
+// order is returned as it is.
+func (o RotationOrder) Reverse() RotationOrder {
+	if !o.valid() {
+		return o
+	}
+	a := o.Axes()
+	for i, r := range rotationOrders {
+		if r.axes == [3]int{a[2], a[1], a[0]} {
+			return RotationOrder(i)
+		}
+	}
+	return o
+}
+
+// EulerAngles are three rotations, A, B and C, about the three axes of
+// Order.  With an invalid Order they are no rotation at all.
+//
+// If Extrinsic is false the rotations are intrinsic: each one is about the
+// axes as already rotated by the ones before it, so the matrix for XYZ is
+// Rx * Ry * Rz.  If Extrinsic is true they are about the fixed world axes
+// and the matrix for XYZ is Rz * Ry * Rx.  Intrinsic XYZ with angles A, B, C
+// is the same rotation as extrinsic ZYX with angles C, B, A.
+type EulerAngles struct {
+	Order     RotationOrder
+	Extrinsic bool
+	A, B, C   Radian
+}
+
+// intrinsic returns the same rotation as intrinsic angles.
+func (e EulerAngles) intrinsic() EulerAngles {
+	if !e.Extrinsic {
+		return e
+	}
+	return EulerAngles{e.Order.Reverse(), false, e.C, e.B, e.A}
+}
+
+// M33 converts to a rotation matrix.
+func (e EulerAngles) M33() M33 {
+	if !e.Order.valid() {
+		return IdentityM33()
+	}
+	e = e.intrinsic()
+	a := e.Order.Axes()
+	return RotateAxisM33(unitAxes[a[0]], e.A).
+		Mult(RotateAxisM33(unitAxes[a[1]], e.B)).
+		Mult(RotateAxisM33(unitAxes[a[2]], e.C))
+}
+
+// Q converts to a quaternion.
+func (e EulerAngles) Q() Q {
+	if !e.Order.valid() {
+		return IdentityQ()
+	}
+	e = e.intrinsic()
+	a := e.Order.Axes()
+	return AxisAngleQ(unitAxes[a[0]], e.A).
+		Mult(AxisAngleQ(unitAxes[a[1]], e.B)).
+		Mult(AxisAngleQ(unitAxes[a[2]], e.C))
+}
+
+// Euler converts to the X, Y, Z form.
+func (e EulerAngles) Euler() Euler {
+	return e.M33().Euler()
+}
+
+// EulerAngles finds the angles that make up a rotation matrix in the given
+// order.  The middle angle B is in -90° to 90° for Tait–Bryan orders and 0°
+// to 180° for proper Euler orders, and the others are in -180° to 180°.
+// At the gimbal lock (where the first and last axes line up) the first
+// intrinsic rotation is zero and the last gets all of it.  The result
+// always converts back to the same matrix.  An invalid order gives zero
+// angles.
+func (m M33) EulerAngles(order RotationOrder, extrinsic bool) EulerAngles {
+	if !order.valid() {
+		return EulerAngles{Order: order, Extrinsic: extrinsic}
+	}
+	o := order
+	if extrinsic {
+		o = o.Reverse()
+	}
+
+	ax := o.Axes()
+	i, j := ax[0], ax[1]
+	k := 3 - i - j
+
+	// row r, column c
+	at := func(r, c int) float64 { return m[c*3+r] }
+
+	// +1 for cyclic orders (XYZ, YZX, ZXY), -1 otherwise
+	var s float64 = 1
+	if (j-i+3)%3 != 1 {
+		s = -1
+	}
+
+	var a, b float64
+	if ax[2] == i {
+		// proper Euler: R_i(a) R_j(b) R_i(c)
+		sb := math.Hypot(at(i, j), at(i, k))
+		b = math.Atan2(sb, at(i, i))
+		if sb > 16*epsilon {
+			a = math.Atan2(at(j, i), -s*at(k, i))
+		}
+	} else {
+		// Tait–Bryan: R_i(a) R_j(b) R_k(c)
+		cb := math.Hypot(at(i, i), at(i, j))
+		b = math.Atan2(s*at(i, k), cb)
+		if cb > 16*epsilon {
+			a = math.Atan2(-s*at(j, k), at(k, k))
+		}
+	}
+
+	// Take the first rotation back off, leaving R_j(b) times the last
+	// rotation.  Row j of that is just row j of the last rotation, so we
+	// can get c from it without caring how close to gimbal lock we are.
+	n := RotateAxisM33(unitAxes[i], Radian(-a)).Mult(m)
+	at = func(r, c int) float64 { return n[c*3+r] }
+
+	var c float64
+	if ax[2] == i {
+		c = math.Atan2(-s*at(j, k), at(j, j))
+	} else {
+		c = math.Atan2(s*at(j, i), at(j, j))
+	}
+
+	if extrinsic {
+		return EulerAngles{order, true, Radian(c), Radian(b), Radian(a)}
+	}
+	return EulerAngles{order, false, Radian(a), Radian(b), Radian(c)}
+}
+
+// EulerAngles finds the angles that make up the rotation in the given
+// order.  See M33.EulerAngles.
+func (q Q) EulerAngles(order RotationOrder, extrinsic bool) EulerAngles {
+	return q.Normalize().M33().EulerAngles(order, extrinsic)
+}
@@ -59,3 +59,107 @@ func (f Frustum) M44() (m M44) {
 
 	return
 }
+
+// Visibility is the result of testing something against a set of
+// FrustumPlanes.
+type Visibility int
+
+const (
+	Outside Visibility = iota
+	Intersecting
+	Inside
+)
+
+func (v Visibility) String() string {
+	switch v {
+	case Outside:
+		return "outside"
+	case Intersecting:
+		return "intersecting"
+	case Inside:
+		return "inside"
+	}
+	return "invalid"
+}
+
+// FrustumPlanes holds the six clipping planes of a view volume, in the order
+// left, right, bottom, top, near, far.  The normals all point inward.
+type FrustumPlanes [6]Plane
+
+// FrustumPlanes extracts the clipping planes from a projection matrix using
+// the Gribb/Hartmann method.  If m is a projection matrix the planes are in
+// view space, if m is projection * modelview they are in world space.
+func (m M44) FrustumPlanes() (f FrustumPlanes) {
+	// rows of the (column major) matrix
+	r0 := V4{m[0], m[4], m[8], m[12]}
+	r1 := V4{m[1], m[5], m[9], m[13]}
+	r2 := V4{m[2], m[6], m[10], m[14]}
+	r3 := V4{m[3], m[7], m[11], m[15]}
+
+	plane := func(a V4, s float64, b V4) Plane {
+		return Plane{
+			V3{a.X + s*b.X, a.Y + s*b.Y, a.Z + s*b.Z},
+			a.W + s*b.W,
+		}.Normalize()
+	}
+
+	f[0] = plane(r3, 1, r0)
+	f[1] = plane(r3, -1, r0)
+	f[2] = plane(r3, 1, r1)
+	f[3] = plane(r3, -1, r1)
+	f[4] = plane(r3, 1, r2)
+	f[5] = plane(r3, -1, r2)
+	return
+}
+
+// ClassifyPoint returns Inside or Outside.
+func (f FrustumPlanes) ClassifyPoint(v V3) Visibility {
+	for _, p := range f {
+		if p.SignedDistance(v) < 0 {
+			return Outside
+		}
+	}
+	return Inside
+}
+
+func (f FrustumPlanes) ClassifySphere(s Sphere) Visibility {
+	out := Inside
+	for _, p := range f {
+		d := p.SignedDistance(s.Center)
+		if d < -s.Radius {
+			return Outside
+		}
+		if d < s.Radius {
+			out = Intersecting
+		}
+	}
+	return out
+}
+
+// ClassifyAABB3 tests the corners of the box nearest and furthest along each
+// plane normal.  Like most frustum tests it is conservative: a box near a
+// corner of the frustum can be reported as Intersecting when it is really
+// just outside.
+func (f FrustumPlanes) ClassifyAABB3(b AABB3) Visibility {
+	out := Inside
+	for _, p := range f {
+		far, near := b.Max, b.Min
+		if p.Normal.X < 0 {
+			far.X, near.X = near.X, far.X
+		}
+		if p.Normal.Y < 0 {
+			far.Y, near.Y = near.Y, far.Y
+		}
+		if p.Normal.Z < 0 {
+			far.Z, near.Z = near.Z, far.Z
+		}
+
+		if p.SignedDistance(far) < 0 {
+			return Outside
+		}
+		if p.SignedDistance(near) < 0 {
+			out = Intersecting
+		}
+	}
+	return out
+}
@@ -0,0 +1,137 @@
+//go:build ignore
+
+// gen32 writes the vector32 package, a float32 copy of this package.
+// Run it with go generate after changing anything here.
+//
+// Every non-test file is copied with float64 swapped for float32, and with
+// package math swapped for internal/math32 which has float32 versions of
+// the same functions.  Files that import vector32 themselves (the
+// conversions between the two) are left out.
+//
+// Float literals too small to matter at float32 precision (usually a
+// tolerance picked for float64) are warned about, as they'd be copied
+// as is.  Scale tolerances from epsilon instead.
+//
+// The -o flag writes somewhere other than vector32, which the tests use to
+// check that vector32 is up to date.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"log"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	header  = "// Code generated by gen32.go; DO NOT EDIT.\n\n"
+	pkg32   = "github.com/yobert/vector/vector32"
+	math32  = "github.com/yobert/vector/internal/math32"
+	pkgName = "vector32"
+)
+
+// epsilon32 is the gap between 1 and the next float32.
+const epsilon32 = 0x1p-23
+
+var outDir = flag.String("o", "vector32", "output directory")
+
+func main() {
+	log.SetFlags(0)
+	flag.Parse()
+
+	files, err := filepath.Glob("*.go")
+	if err != nil {
+		log.Fatal(err)
+	}
+	sort.Strings(files)
+
+	// clear out anything we generated last time, so removed files go away
+	old, err := filepath.Glob(filepath.Join(*outDir, "*.go"))
+	if err != nil {
+		log.Fatal(err)
+	}
+	for _, name := range old {
+		buf, err := os.ReadFile(name)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if bytes.HasPrefix(buf, []byte(header)) {
+			if err := os.Remove(name); err != nil {
+				log.Fatal(err)
+			}
+		}
+	}
+
+	for _, name := range files {
+		if name == "gen32.go" || name == "doc.go" || strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		if err := convert(name); err != nil {
+			log.Fatal(err)
+		}
+	}
+}
+
+func convert(name string) error {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, name, nil, parser.ParseComments)
+	if err != nil {
+		return err
+	}
+
+	for _, imp := range f.Imports {
+		path, _ := strconv.Unquote(imp.Path.Value)
+		if path == pkg32 {
+			return nil
+		}
+		if path == "math" {
+			imp.Name = ast.NewIdent("math")
+			imp.Path.Value = strconv.Quote(math32)
+		}
+	}
+
+	f.Name.Name = pkgName
+
+	ast.Inspect(f, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.Ident:
+			if n.Name == "float64" {
+				n.Name = "float32"
+			}
+		case *ast.SelectorExpr:
+			// rand.Float64, math.MaxFloat64 and friends
+			n.Sel.Name = strings.Replace(n.Sel.Name, "Float64", "Float32", -1)
+		case *ast.BasicLit:
+			if n.Kind != token.FLOAT {
+				break
+			}
+			v, err := strconv.ParseFloat(n.Value, 64)
+			if err == nil && v != 0 && math.Abs(v) < epsilon32 {
+				log.Printf("%s: warning: float literal %s is below float32 precision", fset.Position(n.Pos()), n.Value)
+			}
+		}
+		return true
+	})
+
+	var buf bytes.Buffer
+	buf.WriteString(header)
+	if err := format.Node(&buf, fset, f); err != nil {
+		return err
+	}
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(filepath.Join(*outDir, name), src, 0644)
+}
@@ -0,0 +1,46 @@
+package vector
+
+import "sort"
+
+// GradientStop is a color at a position along a Gradient.
+type GradientStop struct {
+	Pos   float64
+	Color RGBA
+}
+
+// Gradient blends between a list of colors.  Stops must be sorted by Pos;
+// Add keeps them that way.
+type Gradient struct {
+	Stops []GradientStop
+	Space ColorSpace
+}
+
+// Add puts a new stop in the right place.  A stop at the same position as
+// an existing one goes after it, which makes a hard edge.
+func (g *Gradient) Add(pos float64, c RGBA) {
+	i := sort.Search(len(g.Stops), func(i int) bool { return g.Stops[i].Pos > pos })
+	g.Stops = append(g.Stops, GradientStop{})
+	copy(g.Stops[i+1:], g.Stops[i:])
+	g.Stops[i] = GradientStop{pos, c}
+}
+
+// At samples the gradient at t.  Before the first stop and after the last
+// one the end colors carry on.  An empty gradient is transparent.
+func (g Gradient) At(t float64) RGBA {
+	n := len(g.Stops)
+	if n == 0 {
+		return RGBA{}
+	}
+
+	// first stop past t
+	i := sort.Search(n, func(i int) bool { return g.Stops[i].Pos > t })
+	if i == 0 {
+		return g.Stops[0].Color
+	}
+	if i == n {
+		return g.Stops[n-1].Color
+	}
+
+	a, b := g.Stops[i-1], g.Stops[i]
+	return a.Color.Lerp(b.Color, (t-a.Pos)/(b.Pos-a.Pos), g.Space)
+}
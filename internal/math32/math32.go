@@ -0,0 +1,68 @@
+// Package math32 wraps the parts of package math used by package vector so
+// that they take and return float32.  The generated vector32 package
+// imports it in place of math.
+package math32
+
+import "math"
+
+const (
+	E       = math.E
+	Pi      = math.Pi
+	Phi     = math.Phi
+	Sqrt2   = math.Sqrt2
+	SqrtE   = math.SqrtE
+	SqrtPi  = math.SqrtPi
+	SqrtPhi = math.SqrtPhi
+	Ln2     = math.Ln2
+	Log2E   = math.Log2E
+	Ln10    = math.Ln10
+	Log10E  = math.Log10E
+
+	MaxFloat32             = math.MaxFloat32
+	SmallestNonzeroFloat32 = math.SmallestNonzeroFloat32
+
+	MaxInt   = math.MaxInt
+	MinInt   = math.MinInt
+	MaxInt32 = math.MaxInt32
+	MinInt32 = math.MinInt32
+)
+
+func Abs(x float32) float32         { return float32(math.Abs(float64(x))) }
+func Acos(x float32) float32        { return float32(math.Acos(float64(x))) }
+func Asin(x float32) float32        { return float32(math.Asin(float64(x))) }
+func Atan(x float32) float32        { return float32(math.Atan(float64(x))) }
+func Atan2(y, x float32) float32    { return float32(math.Atan2(float64(y), float64(x))) }
+func Cbrt(x float32) float32        { return float32(math.Cbrt(float64(x))) }
+func Ceil(x float32) float32        { return float32(math.Ceil(float64(x))) }
+func Copysign(f, s float32) float32 { return float32(math.Copysign(float64(f), float64(s))) }
+func Cos(x float32) float32         { return float32(math.Cos(float64(x))) }
+func Exp(x float32) float32         { return float32(math.Exp(float64(x))) }
+func Floor(x float32) float32       { return float32(math.Floor(float64(x))) }
+func Hypot(p, q float32) float32    { return float32(math.Hypot(float64(p), float64(q))) }
+func Inf(sign int) float32          { return float32(math.Inf(sign)) }
+func IsInf(f float32, sign int) bool {
+	return math.IsInf(float64(f), sign)
+}
+func IsNaN(f float32) bool           { return f != f }
+func Log(x float32) float32          { return float32(math.Log(float64(x))) }
+func Log2(x float32) float32         { return float32(math.Log2(float64(x))) }
+func Max(x, y float32) float32       { return float32(math.Max(float64(x), float64(y))) }
+func Min(x, y float32) float32       { return float32(math.Min(float64(x), float64(y))) }
+func Mod(x, y float32) float32       { return float32(math.Mod(float64(x), float64(y))) }
+func NaN() float32                   { return float32(math.NaN()) }
+func Nextafter(x, y float32) float32 { return math.Nextafter32(x, y) }
+func Pow(x, y float32) float32       { return float32(math.Pow(float64(x), float64(y))) }
+func Round(x float32) float32        { return float32(math.Round(float64(x))) }
+func Signbit(x float32) bool         { return math.Signbit(float64(x)) }
+func Sin(x float32) float32          { return float32(math.Sin(float64(x))) }
+func Sqrt(x float32) float32         { return float32(math.Sqrt(float64(x))) }
+func Tan(x float32) float32          { return float32(math.Tan(float64(x))) }
+func Trunc(x float32) float32        { return float32(math.Trunc(float64(x))) }
+
+func Sincos(x float32) (sin, cos float32) {
+	s, c := math.Sincos(float64(x))
+	return float32(s), float32(c)
+}
+
+func Float32bits(f float32) uint32     { return math.Float32bits(f) }
+func Float32frombits(b uint32) float32 { return math.Float32frombits(b) }
@@ -0,0 +1,69 @@
+package vector
+
+import "math"
+
+// Line2 is a 2D line segment from Start to End.
+type Line2 struct {
+	Start V2
+	End   V2
+}
+
+func (l Line2) Lerp(v float64) V2 {
+	return l.End.Sub(l.Start).Scale(v).Add(l.Start)
+}
+
+// Intersect finds where two segments cross, using the method from
+// https://stackoverflow.com/a/565282.  If they cross at a single point the
+// result starts and ends at that point.  If they're collinear and overlap,
+// the result is the overlapping part, in the direction of l.
+func (l Line2) Intersect(o Line2) (Line2, bool) {
+	r := l.End.Sub(l.Start)
+	s := o.End.Sub(o.Start)
+
+	// points are easier as the second segment
+	if r.LenSq() == 0 && s.LenSq() != 0 {
+		hit, ok := o.Intersect(l)
+		return Line2{hit.Start, hit.Start}, ok
+	}
+
+	qp := o.Start.Sub(l.Start)
+	rs := r.Cross(s)
+	tolerance := 16 * epsilon * r.Len() * math.Max(s.Len(), qp.Len())
+
+	if math.Abs(rs) <= tolerance {
+		if math.Abs(qp.Cross(r)) > tolerance {
+			// parallel
+			return Line2{}, false
+		}
+
+		rr := r.LenSq()
+		if rr == 0 {
+			// both are points
+			if qp.LenSq() == 0 {
+				return Line2{l.Start, l.Start}, true
+			}
+			return Line2{}, false
+		}
+
+		// collinear, so find where o starts and ends along l
+		t0 := qp.Dot(r) / rr
+		t1 := t0 + s.Dot(r)/rr
+		if t0 > t1 {
+			t0, t1 = t1, t0
+		}
+		t0 = math.Max(t0, 0)
+		t1 = math.Min(t1, 1)
+		if t0 > t1 {
+			return Line2{}, false
+		}
+		return Line2{l.Lerp(t0), l.Lerp(t1)}, true
+	}
+
+	t := qp.Cross(s) / rs
+	u := qp.Cross(r) / rs
+	if t < 0 || t > 1 || u < 0 || u > 1 {
+		return Line2{}, false
+	}
+	p := l.Lerp(t)
+	return Line2{p, p}, true
+}
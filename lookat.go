@@ -0,0 +1,47 @@
+package vector
+
+// lookAt returns the orientation of something at eye facing target, in the
+// OpenGL camera convention: the columns are right (+X), up (+Y), and
+// backward (+Z), so -Z points at the target.
+func lookAt(eye, target, up V3) M33 {
+	back := eye.Sub(target).Normalize()
+	right := up.Cross(back).Normalize()
+	if right.LenSq() == 0 {
+		// looking straight along up, so any right will do
+		right = V3{1, 0, 0}.Cross(back).Normalize()
+		if right.LenSq() == 0 {
+			right = V3{0, 1, 0}.Cross(back).Normalize()
+		}
+	}
+	up = back.Cross(right)
+
+	return M33{
+		right.X, right.Y, right.Z,
+		up.X, up.Y, up.Z,
+		back.X, back.Y, back.Z,
+	}
+}
+
+// LookAtM44 returns a view (modelview) matrix for a camera at eye looking
+// at target, like gluLookAt.  up doesn't have to be exactly perpendicular
+// to the view direction, but it can't be parallel to it.
+func LookAtM44(eye, target, up V3) M44 {
+	r := lookAt(eye, target, up).Transpose()
+	t := r.MultV3(eye).Scale(-1)
+	m := r.M44()
+	m[12], m[13], m[14] = t.X, t.Y, t.Z
+	return m
+}
+
+// LookAtQ returns the orientation of a camera (or anything else facing
+// down -Z) at eye looking at target.  This is the rotation part of the
+// inverse of LookAtM44.
+func LookAtQ(eye, target, up V3) Q {
+	return lookAt(eye, target, up).Q().Normalize()
+}
+
+// LookAt points the camera at target and updates the modelview matrices.
+func (cam *Camera) LookAt(target, up V3) {
+	cam.RotAxis = lookAt(cam.Position, target, up).Euler()
+	cam.SetupModelView()
+}
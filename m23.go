@@ -0,0 +1,189 @@
+package vector
+
+import (
+	"fmt"
+	"math"
+)
+
+// M23 is a 2D affine transform: a 2x2 matrix for rotation, scale and skew,
+// plus a translation, with the bottom row of the equivalent 3x3 always
+// assumed to be 0, 0, 1.
+//
+// Like M34 it is stored row major: two rows of three, with the translation
+// at the end of each row.  An SVG or canvas matrix(a, b, c, d, e, f) is
+// M23{a, c, e, b, d, f}.
+type M23 [6]float64
+
+func IdentityM23() M23 {
+	return M23{
+		1, 0, 0,
+		0, 1, 0}
+}
+
+func TranslateM23(v V2) M23 {
+	return M23{
+		1, 0, v.X,
+		0, 1, v.Y}
+}
+
+func ScaleM23(v V2) M23 {
+	return M23{
+		v.X, 0, 0,
+		0, v.Y, 0}
+}
+
+// RotateM23 rotates counterclockwise by φ.
+func RotateM23(φ Radian) M23 {
+	s := Sin(φ)
+	c := Cos(φ)
+	return M23{
+		c, -s, 0,
+		s, c, 0}
+}
+
+// SkewM23 skews by angles like CSS skew(x, y): X is shifted by tan(x) per
+// unit of Y, and Y by tan(y) per unit of X.
+func SkewM23(x, y Radian) M23 {
+	return M23{
+		1, Tan(x), 0,
+		Tan(y), 1, 0}
+}
+
+// At returns the element at row, col.
+func (m M23) At(row, col int) float64 {
+	return m[row*3+col]
+}
+
+func (m M23) TranslatePart() V2 {
+	return V2{m[2], m[5]}
+}
+
+// M33 converts to a 2D homogeneous M33, which is column major like M44.
+func (m M23) M33() M33 {
+	return M33{
+		m[0], m[3], 0,
+		m[1], m[4], 0,
+		m[2], m[5], 1}
+}
+
+// M23 drops the bottom row of a 2D homogeneous matrix, which should be
+// 0, 0, 1.
+func (m M33) M23() M23 {
+	return M23{
+		m[0], m[3], m[6],
+		m[1], m[4], m[7]}
+}
+
+// M44 converts to a 3D transform that works in the XY plane and leaves Z
+// alone, for drawing 2D things with a 3D pipeline.
+func (m M23) M44() M44 {
+	return M44{
+		m[0], m[3], 0, 0,
+		m[1], m[4], 0, 0,
+		0, 0, 1, 0,
+		m[2], m[5], 0, 1}
+}
+
+// Mult works like M44.Mult: b gets applied first, then a.
+func (a M23) Mult(b M23) M23 {
+	return M23{
+		a[0]*b[0] + a[1]*b[3],
+		a[0]*b[1] + a[1]*b[4],
+		a[0]*b[2] + a[1]*b[5] + a[2],
+
+		a[3]*b[0] + a[4]*b[3],
+		a[3]*b[1] + a[4]*b[4],
+		a[3]*b[2] + a[4]*b[5] + a[5]}
+}
+
+// MultV2 transforms a point, including the translation.
+func (m M23) MultV2(v V2) V2 {
+	return V2{
+		m[0]*v.X + m[1]*v.Y + m[2],
+		m[3]*v.X + m[4]*v.Y + m[5]}
+}
+
+// MultDir transforms a direction, ignoring the translation.
+func (m M23) MultDir(v V2) V2 {
+	return V2{
+		m[0]*v.X + m[1]*v.Y,
+		m[3]*v.X + m[4]*v.Y}
+}
+
+func (m M23) Determinant() float64 {
+	return m[0]*m[4] - m[1]*m[3]
+}
+
+// Inverse returns the inverse of m, or the identity if m can't be inverted.
+func (m M23) Inverse() M23 {
+	o, _ := m.InverseOK()
+	return o
+}
+
+// InverseOK is Inverse, but also says whether it worked.
+func (m M23) InverseOK() (M23, bool) {
+	// same condition check as M33.InverseOK, using the adjugate's norm
+	det := m.Determinant()
+	n := math.Max(math.Abs(m[0])+math.Abs(m[3]), math.Abs(m[1])+math.Abs(m[4]))
+	adj := math.Max(math.Abs(m[4])+math.Abs(m[3]), math.Abs(m[1])+math.Abs(m[0]))
+	if det == 0 || n*adj > maxCondition*math.Abs(det) {
+		return IdentityM23(), false
+	}
+
+	d := 1 / det
+	a := m[4] * d
+	b := -m[1] * d
+	c := -m[3] * d
+	e := m[0] * d
+	return M23{
+		a, b, -(a*m[2] + b*m[5]),
+		c, e, -(c*m[2] + e*m[5])}, true
+}
+
+// Decompose breaks an affine transform into parts so that
+//
+//	m = translate * rotate * skew * scale
+//
+// skew is the amount of X added per unit of Y.  If the transform flips
+// (negative determinant) the X scale is negated, like M44.Decompose.
+func (m M23) Decompose() (translate V2, rotate Radian, scale V2, skew float64) {
+	translate = m.TranslatePart()
+
+	c0 := V2{m[0], m[3]}
+	c1 := V2{m[1], m[4]}
+
+	scale.X = c0.Len()
+	c0 = c0.Normalize()
+
+	skew = c0.Dot(c1)
+	c1 = c1.Sub(c0.Scale(skew))
+	scale.Y = c1.Len()
+	c1 = c1.Normalize()
+
+	if scale.Y != 0 {
+		skew /= scale.Y
+	}
+
+	if c0.Cross(c1) < 0 {
+		scale.X = -scale.X
+		skew = -skew
+		c0 = c0.Scale(-1)
+	}
+
+	rotate = c0.Angle()
+	return
+}
+
+// ComposeM23 builds a transform from the parts returned by Decompose.
+func ComposeM23(translate V2, rotate Radian, scale V2, skew float64) M23 {
+	h := M23{
+		1, skew, 0,
+		0, 1, 0}
+	return TranslateM23(translate).Mult(RotateM23(rotate)).Mult(h).Mult(ScaleM23(scale))
+}
+
+func (m M23) String() string {
+	return fmt.Sprintf("[\t%.2f\t%.2f\t%.2f\n\t%.2f\t%.2f\t%.2f\t]\n",
+		m[0], m[1], m[2],
+		m[3], m[4], m[5])
+}
@@ -5,8 +5,69 @@ import (
 	"math"
 )
 
+// M33 is a 3x3 matrix stored in column major order.  See M44.
 type M33 [9]float64
 
+// RowsM33 builds a matrix from its rows.
+func RowsM33(r0, r1, r2 V3) M33 {
+	return M33{
+		r0.X, r1.X, r2.X,
+		r0.Y, r1.Y, r2.Y,
+		r0.Z, r1.Z, r2.Z}
+}
+
+// ColsM33 builds a matrix from its columns.
+func ColsM33(c0, c1, c2 V3) M33 {
+	return M33{
+		c0.X, c0.Y, c0.Z,
+		c1.X, c1.Y, c1.Z,
+		c2.X, c2.Y, c2.Z}
+}
+
+// At returns the element at row, col (both from 0).
+func (m M33) At(row, col int) float64 {
+	return m[col*3+row]
+}
+
+// Set changes the element at row, col (both from 0).
+func (m *M33) Set(row, col int, v float64) {
+	m[col*3+row] = v
+}
+
+func (m M33) Row(i int) V3 {
+	return V3{m[i], m[3+i], m[6+i]}
+}
+
+func (m M33) Col(i int) V3 {
+	return V3{m[i*3], m[i*3+1], m[i*3+2]}
+}
+
+// ColMajor32 returns the matrix as float32s in column major order, for
+// glUniformMatrix3fv with transpose false.
+func (m M33) ColMajor32() (o [9]float32) {
+	for i, v := range m {
+		o[i] = float32(v)
+	}
+	return
+}
+
+// RowMajor32 returns the matrix as float32s in row major order.
+func (m M33) RowMajor32() (o [9]float32) {
+	for i, v := range m.Transpose() {
+		o[i] = float32(v)
+	}
+	return
+}
+
+// Std140 returns the matrix laid out for a mat3 in a std140 (OpenGL or
+// Vulkan) uniform buffer, where each column is padded out to 4 floats.
+func (m M33) Std140() [12]float32 {
+	return [12]float32{
+		float32(m[0]), float32(m[1]), float32(m[2]), 0,
+		float32(m[3]), float32(m[4]), float32(m[5]), 0,
+		float32(m[6]), float32(m[7]), float32(m[8]), 0}
+}
+
 func IdentityM33() M33 {
 	return M33{
 		1, 0, 0,
@@ -51,6 +112,69 @@ func (a M33) Determinant() float64 {
 		a[2]*a[4]*a[6] - a[1]*a[3]*a[8] - a[0]*a[5]*a[7]
 }
 
+// Inverse returns the inverse of the matrix, or the identity matrix if it
+// can't be inverted.  Use InverseOK if you need to know.
+func (m M33) Inverse() M33 {
+	o, _ := m.inverse()
+	return o
+}
+
+// InverseOK returns the inverse of the matrix, and false if the matrix is
+// singular or so badly conditioned that the inverse would be mostly noise.
+func (m M33) InverseOK() (M33, bool) {
+	o, ok := m.inverse()
+	if !ok || m.norm1()*o.norm1() > maxCondition {
+		return IdentityM33(), false
+	}
+	return o, true
+}
+
+func (a M33) inverse() (M33, bool) {
+	// this is the usual row major formula, but since the inverse of the
+	// transpose is the transpose of the inverse it works column major too.
+	c0 := a[4]*a[8] - a[5]*a[7]
+	c3 := a[5]*a[6] - a[3]*a[8]
+	c6 := a[3]*a[7] - a[4]*a[6]
+
+	det := a[0]*c0 + a[1]*c3 + a[2]*c6
+	if det == 0.0 {
+		return IdentityM33(), false
+	}
+
+	id := 1.0 / det
+
+	return M33{
+		id * c0,
+		id * (a[2]*a[7] - a[1]*a[8]),
+		id * (a[1]*a[5] - a[2]*a[4]),
+		id * c3,
+		id * (a[0]*a[8] - a[2]*a[6]),
+		id * (a[2]*a[3] - a[0]*a[5]),
+		id * c6,
+		id * (a[1]*a[6] - a[0]*a[7]),
+		id * (a[0]*a[4] - a[1]*a[3]),
+	}, true
+}
+
+// Cond returns the condition number of the matrix (in the 1-norm), which
+// is roughly how much error gets amplified by inverting it.  1 is perfect,
+// rotation matrices are close to 1, and singular matrices are +Inf.
+func (m M33) Cond() float64 {
+	o, ok := m.inverse()
+	if !ok {
+		return math.Inf(1)
+	}
+	return m.norm1() * o.norm1()
+}
+
+// norm1 is the largest absolute column sum.
+func (m M33) norm1() (n float64) {
+	for c := 0; c < 3; c++ {
+		n = math.Max(n, math.Abs(m[c*3])+math.Abs(m[c*3+1])+math.Abs(m[c*3+2]))
+	}
+	return
+}
+
 func (a M33) Mult(b M33) M33 {
 	return M33{
 		a[0]*b[0] + a[3]*b[1] + a[6]*b[2],
@@ -67,7 +191,8 @@ func (a M33) Mult(b M33) M33 {
 }
 
 // OpenGL style matrix multiplication:
-// it is actually Mult() with the operands switched
+// it is actually Mult() with the operands switched, so a.MultX(b) is
+// b.Mult(a) and applies a first.
 func (a M33) MultX(b M33) (o M33) {
 	for i := 0; i < 3; i++ {
 		for j := 0; j < 3; j++ {
@@ -138,6 +263,13 @@ func (m M33) Q() Q {
 	}
 }
 
+// Euler is the reverse of Euler.M33: it finds the rotations about X, then
+// Y, then Z that make up this rotation matrix.  See M33.EulerAngles.
+func (m M33) Euler() Euler {
+	e := m.EulerAngles(XYZ, true)
+	return Euler{e.A, e.B, e.C}
+}
+
 func (m M33) String() string {
 	return fmt.Sprintf("[\t%.2f\t%.2f\t%.2f\n\t%.2f\t%.2f\t%.2f\n\t%.2f\t%.2f\t%.2f\t]",
 		m[0], m[3], m[6],
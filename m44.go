@@ -1,9 +1,81 @@
 package vector
 
-import "fmt"
+import (
+	"fmt"
+	"math"
+)
 
+// M44 is a 4x4 matrix stored in column major order, the same as OpenGL:
+// m[0], m[1], m[2], m[3] is the first column, and the translation lives in
+// m[12], m[13], m[14].  Vectors are columns multiplied on the right, so
+// a.Mult(b) applies b first and then a.  Use At and Set to get at elements
+// by row and column without worrying about any of that.
 type M44 [16]float64
 
+// RowsM44 builds a matrix from its rows, so the literal reads the way the
+// matrix is written down on paper.
+func RowsM44(r0, r1, r2, r3 V4) M44 {
+	return M44{
+		r0.X, r1.X, r2.X, r3.X,
+		r0.Y, r1.Y, r2.Y, r3.Y,
+		r0.Z, r1.Z, r2.Z, r3.Z,
+		r0.W, r1.W, r2.W, r3.W}
+}
+
+// ColsM44 builds a matrix from its columns.
+func ColsM44(c0, c1, c2, c3 V4) M44 {
+	return M44{
+		c0.X, c0.Y, c0.Z, c0.W,
+		c1.X, c1.Y, c1.Z, c1.W,
+		c2.X, c2.Y, c2.Z, c2.W,
+		c3.X, c3.Y, c3.Z, c3.W}
+}
+
+// At returns the element at row, col (both from 0).
+func (m M44) At(row, col int) float64 {
+	return m[col*4+row]
+}
+
+// Set changes the element at row, col (both from 0).
+func (m *M44) Set(row, col int, v float64) {
+	m[col*4+row] = v
+}
+
+func (m M44) Row(i int) V4 {
+	return V4{m[i], m[4+i], m[8+i], m[12+i]}
+}
+
+func (m M44) Col(i int) V4 {
+	return V4{m[i*4], m[i*4+1], m[i*4+2], m[i*4+3]}
+}
+
+// ColMajor32 returns the matrix as float32s in column major order, ready for
+// glUniformMatrix4fv with transpose false, or a GLSL or Vulkan mat4.
+func (m M44) ColMajor32() (o [16]float32) {
+	for i, v := range m {
+		o[i] = float32(v)
+	}
+	return
+}
+
+// RowMajor32 returns the matrix as float32s in row major order, for APIs
+// and shaders (HLSL row_major, or Direct3D style row vectors) that want it
+// the other way around.
+func (m M44) RowMajor32() (o [16]float32) {
+	for i, v := range m.Transpose() {
+		o[i] = float32(v)
+	}
+	return
+}
+
+func (m M44) Transpose() M44 {
+	return M44{
+		m[0], m[4], m[8], m[12],
+		m[1], m[5], m[9], m[13],
+		m[2], m[6], m[10], m[14],
+		m[3], m[7], m[11], m[15]}
+}
+
 func IdentityM44() M44 {
 	return M44{
 		1, 0, 0, 0,
@@ -52,7 +124,8 @@ func (a M44) Mult(b M44) M44 {
 }
 
 // OpenGL style matrix multiplication:
-// it is actually Mult() with the operands switched
+// it is actually Mult() with the operands switched, so a.MultX(b) is
+// b.Mult(a) and applies a first.
 func (a M44) MultX(b M44) (o M44) {
 	for i := 0; i < 4; i++ {
 		for j := 0; j < 4; j++ {
@@ -81,7 +154,61 @@ func (m M44) MultV4(vec V4) (out V4) {
 	return
 }
 
+// Inverse returns the inverse of the matrix, or the identity matrix if it
+// can't be inverted.  Use InverseOK if you need to know.
 func (m M44) Inverse() M44 {
+	o, _ := m.inverse()
+	return o
+}
+
+// InverseOK returns the inverse of the matrix, and false if the matrix is
+// singular or so badly conditioned that the inverse would be mostly noise.
+func (m M44) InverseOK() (M44, bool) {
+	o, ok := m.inverse()
+	if !ok || m.norm1()*o.norm1() > maxCondition {
+		return IdentityM44(), false
+	}
+	return o, true
+}
+
+// InverseAffine is a faster inverse for matrices whose bottom row is
+// 0, 0, 0, 1, ie. anything built from translation, rotation, scale and shear.
+func (m M44) InverseAffine() M44 {
+	r := m.M33().Inverse()
+	t := r.MultV3(m.TranslatePart()).Scale(-1)
+	o := r.M44()
+	o[12], o[13], o[14] = t.X, t.Y, t.Z
+	return o
+}
+
+// InverseRigid is the fastest inverse, but only works for matrices made of
+// just rotation and translation.
+func (m M44) InverseRigid() M44 {
+	r := m.M33().Transpose()
+	t := r.MultV3(m.TranslatePart()).Scale(-1)
+	o := r.M44()
+	o[12], o[13], o[14] = t.X, t.Y, t.Z
+	return o
+}
+
+// Cond returns the condition number of the matrix.  See M33.Cond.
+func (m M44) Cond() float64 {
+	o, ok := m.inverse()
+	if !ok {
+		return math.Inf(1)
+	}
+	return m.norm1() * o.norm1()
+}
+
+// norm1 is the largest absolute column sum.
+func (m M44) norm1() (n float64) {
+	for c := 0; c < 4; c++ {
+		n = math.Max(n, math.Abs(m[c*4])+math.Abs(m[c*4+1])+math.Abs(m[c*4+2])+math.Abs(m[c*4+3]))
+	}
+	return
+}
+
+func (m M44) inverse() (M44, bool) {
 	a0 := m[0]*m[5] - m[4]*m[1]
 	a1 := m[0]*m[9] - m[8]*m[1]
 	a2 := m[0]*m[13] - m[12]*m[1]
@@ -98,7 +225,7 @@ func (m M44) Inverse() M44 {
 	det := a0*b5 - a1*b4 + a2*b3 + a3*b2 - a4*b1 + a5*b0
 
 	if det == 0.0 {
-		return IdentityM44()
+		return IdentityM44(), false
 	}
 
 	id := 1.0 / det
@@ -120,7 +247,7 @@ func (m M44) Inverse() M44 {
 		id * (+m[2]*a5 - m[10]*a2 + m[14]*a1),
 		id * (-m[2]*a4 + m[6]*a2 - m[14]*a0),
 		id * (+m[2]*a3 - m[6]*a1 + m[10]*a0),
-	}
+	}, true
 }
 
 // M33 will truncate the 4x4 matrix down to a 3x3
@@ -136,8 +263,114 @@ func (a M44) TranslatePart() V3 {
 	return V3{a[12], a[13], a[14]}
 }
 
+// ScalePart returns the per-axis scale, taking any rotation into account.
+// See Decompose.
 func (a M44) ScalePart() V3 {
-	return V3{a[0], a[5], a[10]}
+	_, _, scale, _, _ := a.Decompose()
+	return scale
+}
+
+// Decompose breaks a matrix down into the pieces that ComposeM44 will
+// put back together:
+//
+//	m = perspective * translate * rotate * shear * scale
+//
+// shear.X is the amount of X added per unit of Y, shear.Y is X per unit of
+// Z, and shear.Z is Y per unit of Z.  perspective is the bottom row of the
+// projection part, and is {0, 0, 0, 1} for any affine matrix.
+//
+// If the matrix flips handedness (negative determinant) the X scale is
+// negated, so the rotation is always a proper rotation.  A matrix with a
+// zero scale on any axis has no meaningful rotation and the result is
+// undefined.
+func (m M44) Decompose() (translate V3, rotate Q, scale V3, shear V3, perspective V4) {
+	perspective = V4{0, 0, 0, 1}
+
+	// pull off the projection part first, if there is one
+	a := m
+	a[3], a[7], a[11], a[15] = 0, 0, 0, 1
+	if m[3] != 0 || m[7] != 0 || m[11] != 0 || m[15] != 1 {
+		// bottom row of m is perspective times a, so solve for perspective
+		// using the rows of a's inverse.
+		ai := a.Inverse()
+		perspective = V4{
+			m[3]*ai[0] + m[7]*ai[1] + m[11]*ai[2] + m[15]*ai[3],
+			m[3]*ai[4] + m[7]*ai[5] + m[11]*ai[6] + m[15]*ai[7],
+			m[3]*ai[8] + m[7]*ai[9] + m[11]*ai[10] + m[15]*ai[11],
+			m[3]*ai[12] + m[7]*ai[13] + m[11]*ai[14] + m[15]*ai[15],
+		}
+	}
+
+	translate = a.TranslatePart()
+
+	// Gram-Schmidt the columns, keeping track of what we removed
+	c0 := V3{a[0], a[1], a[2]}
+	c1 := V3{a[4], a[5], a[6]}
+	c2 := V3{a[8], a[9], a[10]}
+
+	scale.X = c0.Len()
+	c0 = c0.Normalize()
+
+	shear.X = c0.Dot(c1)
+	c1 = c1.Sub(c0.Scale(shear.X))
+	scale.Y = c1.Len()
+	c1 = c1.Normalize()
+
+	shear.Y = c0.Dot(c2)
+	c2 = c2.Sub(c0.Scale(shear.Y))
+	shear.Z = c1.Dot(c2)
+	c2 = c2.Sub(c1.Scale(shear.Z))
+	scale.Z = c2.Len()
+	c2 = c2.Normalize()
+
+	if scale.Y != 0 {
+		shear.X /= scale.Y
+	}
+	if scale.Z != 0 {
+		shear.Y /= scale.Z
+		shear.Z /= scale.Z
+	}
+
+	if c0.Cross(c1).Dot(c2) < 0 {
+		scale.X = -scale.X
+		shear.X = -shear.X
+		shear.Y = -shear.Y
+		c0 = c0.Scale(-1)
+	}
+
+	rotate = M33{
+		c0.X, c0.Y, c0.Z,
+		c1.X, c1.Y, c1.Z,
+		c2.X, c2.Y, c2.Z,
+	}.Q().Normalize()
+
+	return
+}
+
+// ComposeM44 builds a matrix from the parts returned by Decompose.
+func ComposeM44(translate V3, rotate Q, scale V3, shear V3, perspective V4) M44 {
+	r := rotate.Normalize().M33()
+	h := M33{
+		1, 0, 0,
+		shear.X, 1, 0,
+		shear.Y, shear.Z, 1,
+	}
+	s := M33{
+		scale.X, 0, 0,
+		0, scale.Y, 0,
+		0, 0, scale.Z,
+	}
+
+	m := r.Mult(h).Mult(s).M44()
+	m[12], m[13], m[14] = translate.X, translate.Y, translate.Z
+
+	if perspective != (V4{0, 0, 0, 1}) {
+		p := IdentityM44()
+		p[3], p[7], p[11], p[15] = perspective.X, perspective.Y, perspective.Z, perspective.W
+		m = p.Mult(m)
+	}
+
+	return m
 }
 
 func (m M44) String() string {
@@ -0,0 +1,177 @@
+package vector
+
+// Node is a scene graph node: a local Transform relative to its parent, and
+// any number of children.  The world matrix and its inverse are only
+// recomputed when something above them has changed.
+//
+// The zero value is not ready to use; make nodes with NewNode.
+type Node struct {
+	parent   *Node
+	children []*Node
+
+	local Transform
+
+	world        M44
+	worldInverse M44
+
+	// if a node is dirty, so are all of its children
+	dirty        bool
+	inverseDirty bool
+}
+
+func NewNode() *Node {
+	return &Node{
+		local:        IdentityTransform(),
+		dirty:        true,
+		inverseDirty: true,
+	}
+}
+
+func (n *Node) Parent() *Node {
+	return n.parent
+}
+
+// Children returns the node's children.  Don't modify the slice; use
+// AddChild, SetParent or Detach instead.
+func (n *Node) Children() []*Node {
+	return n.children
+}
+
+func (n *Node) Local() Transform {
+	return n.local
+}
+
+func (n *Node) SetLocal(t Transform) {
+	n.local = t
+	n.markDirty()
+}
+
+func (n *Node) markDirty() {
+	if n.dirty {
+		return
+	}
+	n.dirty = true
+	n.inverseDirty = true
+	for _, c := range n.children {
+		c.markDirty()
+	}
+}
+
+// World returns the matrix taking the node's local space to world space.
+func (n *Node) World() M44 {
+	if n.dirty {
+		n.world = n.local.M44()
+		if n.parent != nil {
+			n.world = n.parent.World().Mult(n.world)
+		}
+		n.dirty = false
+	}
+	return n.world
+}
+
+// WorldInverse returns the matrix taking world space to the node's local
+// space.
+func (n *Node) WorldInverse() M44 {
+	w := n.World()
+	if n.inverseDirty {
+		n.worldInverse = w.Inverse()
+		n.inverseDirty = false
+	}
+	return n.worldInverse
+}
+
+// WorldPosition returns where the node's origin is in world space.
+func (n *Node) WorldPosition() V3 {
+	return n.World().MultV3(V3{})
+}
+
+// IsAncestor reports whether a is above n in the tree.
+func (n *Node) IsAncestor(a *Node) bool {
+	for p := n.parent; p != nil; p = p.parent {
+		if p == a {
+			return true
+		}
+	}
+	return false
+}
+
+// SetParent moves the node under p (or makes it a root if p is nil),
+// keeping its local transform, so it will move in world space unless the
+// old and new parents line up.  It panics if p is n or one of n's
+// children.
+func (n *Node) SetParent(p *Node) {
+	if p == n || (p != nil && p.IsAncestor(n)) {
+		panic("vector: node can't be its own ancestor")
+	}
+	if n.parent == p {
+		return
+	}
+	n.Detach()
+	if p != nil {
+		n.parent = p
+		p.children = append(p.children, n)
+	}
+	n.markDirty()
+}
+
+// Reparent is SetParent, except the local transform is changed so that the
+// node stays put in world space.  Shear can't be held in a Transform, so
+// this is only exact if the scales involved are uniform.
+func (n *Node) Reparent(p *Node) {
+	w := n.World()
+	if p != nil {
+		w = p.WorldInverse().Mult(w)
+	}
+	n.SetParent(p)
+	n.SetLocal(w.Transform())
+}
+
+func (n *Node) AddChild(c *Node) {
+	c.SetParent(n)
+}
+
+// Detach removes the node from its parent, keeping its local transform.
+func (n *Node) Detach() {
+	p := n.parent
+	if p == nil {
+		return
+	}
+	for i, c := range p.children {
+		if c == n {
+			copy(p.children[i:], p.children[i+1:])
+			p.children[len(p.children)-1] = nil
+			p.children = p.children[:len(p.children)-1]
+			break
+		}
+	}
+	n.parent = nil
+	n.markDirty()
+}
+
+// Walk visits n and everything below it depth first, parents before
+// children.  If fn returns false the node's children are skipped.
+func (n *Node) Walk(fn func(*Node) bool) {
+	if !fn(n) {
+		return
+	}
+	for _, c := range n.children {
+		c.Walk(fn)
+	}
+}
+
+// WalkPost visits n and everything below it depth first, children before
+// parents.
+func (n *Node) WalkPost(fn func(*Node)) {
+	for _, c := range n.children {
+		c.WalkPost(fn)
+	}
+	fn(n)
+}
+
+// Root returns the top of the tree n is in.
+func (n *Node) Root() *Node {
+	for n.parent != nil {
+		n = n.parent
+	}
+	return n
+}
@@ -0,0 +1,10 @@
+package vector
+
+// OBB is an oriented bounding box.  The columns of Rotate are the box's
+// local axes, which should be orthonormal, and HalfSize is how far the box
+// extends from Center along each of them.
+type OBB struct {
+	Center   V3
+	Rotate   M33
+	HalfSize V3
+}
@@ -6,3 +6,10 @@ const (
 	π = math.Pi
 	τ = 2 * π
 )
+
+// epsilon is the gap between 1 and the next representable number.
+var epsilon = math.Nextafter(1, 2) - 1
+
+// maxCondition is the largest condition number a matrix can have before we
+// consider it too close to singular to invert usefully.
+var maxCondition = 1 / (256 * epsilon)
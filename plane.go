@@ -0,0 +1,148 @@
+package vector
+
+import "math"
+
+// Plane is the set of points p where Normal.Dot(p) + D == 0.
+// Normal should be normalized, in which case D is the negated distance of
+// the plane from the origin along Normal.
+type Plane struct {
+	Normal V3
+	D      float64
+}
+
+// SignedDistance returns how far v is from the plane, positive on the side
+// the normal points to.  Only correct if Normal is normalized.
+func (p Plane) SignedDistance(v V3) float64 {
+	return p.Normal.Dot(v) + p.D
+}
+
+// Normalize scales the plane equation so that Normal has unit length.
+func (p Plane) Normalize() Plane {
+	l := p.Normal.Len()
+	if l == 0.0 {
+		return Plane{}
+	}
+	return Plane{p.Normal.Scale(1.0 / l), p.D / l}
+}
+
+// PointNormalPlane makes the plane through point facing normal.  The
+// normal doesn't need to be normalized.
+func PointNormalPlane(point, normal V3) Plane {
+	n := normal.Normalize()
+	return Plane{n, -n.Dot(point)}
+}
+
+// PointsPlane makes the plane through a, b and c.  The normal faces the
+// side from which they wind counterclockwise.  If the points are in a line
+// the result has a zero normal.
+func PointsPlane(a, b, c V3) Plane {
+	return PointNormalPlane(a, b.Sub(a).Cross(c.Sub(a)))
+}
+
+// ProjectPoint returns the closest point on the plane to v.
+func (p Plane) ProjectPoint(v V3) V3 {
+	return v.Sub(p.Normal.Scale(p.SignedDistance(v)))
+}
+
+// ReflectPoint mirrors v through the plane.
+func (p Plane) ReflectPoint(v V3) V3 {
+	return v.Sub(p.Normal.Scale(2 * p.SignedDistance(v)))
+}
+
+// PlaneSide says which side of a plane something is on.
+type PlaneSide int
+
+const (
+	PlaneBack PlaneSide = iota - 1
+	PlaneOn
+	PlaneFront
+)
+
+func (s PlaneSide) String() string {
+	switch s {
+	case PlaneBack:
+		return "back"
+	case PlaneOn:
+		return "on"
+	case PlaneFront:
+		return "front"
+	}
+	return "invalid"
+}
+
+// Side classifies v as in front of the plane (the side the normal points
+// to), behind it, or on it if it's within tolerance of the plane.
+func (p Plane) Side(v V3, tolerance float64) PlaneSide {
+	d := p.SignedDistance(v)
+	if d > tolerance {
+		return PlaneFront
+	}
+	if d < -tolerance {
+		return PlaneBack
+	}
+	return PlaneOn
+}
+
+// IntersectPlane returns the line where two planes cross, as a ray with a
+// normalized direction starting at the point on the line closest to the
+// origin.  It fails if the planes are parallel.
+func (a Plane) IntersectPlane(b Plane) (Ray, bool) {
+	dir := a.Normal.Cross(b.Normal)
+	l := dir.LenSq()
+	tolerance := 16 * epsilon * a.Normal.Len() * b.Normal.Len()
+	if l <= tolerance*tolerance {
+		return Ray{}, false
+	}
+
+	// the point is a mix of both normals, solved so it's on both planes
+	origin := a.Normal.Scale(b.D).Sub(b.Normal.Scale(a.D)).Cross(dir).Scale(1 / l)
+	return Ray{origin, dir.Normalize()}, true
+}
+
+// IntersectPlanes returns the one point on all three planes.  It fails if
+// any two of them are parallel or they all meet in a line.
+func IntersectPlanes(a, b, c Plane) (V3, bool) {
+	bc := b.Normal.Cross(c.Normal)
+	denom := a.Normal.Dot(bc)
+	if math.Abs(denom) <= 16*epsilon*a.Normal.Len()*b.Normal.Len()*c.Normal.Len() {
+		return V3{}, false
+	}
+
+	ca := c.Normal.Cross(a.Normal)
+	ab := a.Normal.Cross(b.Normal)
+	return bc.Scale(-a.D).Add(ca.Scale(-b.D)).Add(ab.Scale(-c.D)).Scale(1 / denom), true
+}
+
+// IntersectPlane returns where the line segment crosses the plane, and how
+// far along the segment that is from 0 at Start to 1 at End.  It fails if
+// the segment doesn't reach the plane or runs parallel to it.
+func (l Line) IntersectPlane(p Plane) (V3, float64, bool) {
+	ds := p.SignedDistance(l.Start)
+	de := p.SignedDistance(l.End)
+	if ds == de || (ds > 0 && de > 0) || (ds < 0 && de < 0) {
+		return V3{}, 0, false
+	}
+
+	t := ds / (ds - de)
+	return l.Lerp(t), t, true
+}
+
+// Transform moves the plane by m.  Planes transform by the inverse
+// transpose, so that normals stay perpendicular even when m scales
+// unevenly.  The result is normalized.
+func (p Plane) Transform(m M44) Plane {
+	v := m.Inverse().Transpose().MultV4(V4{p.Normal.X, p.Normal.Y, p.Normal.Z, p.D})
+	return Plane{V3{v.X, v.Y, v.Z}, v.W}.Normalize()
+}
+
+// ReflectM44 mirrors everything through the plane, which should be
+// normalized.  Use it for rendering mirrors, and remember that it flips
+// the triangle winding.
+func ReflectM44(p Plane) M44 {
+	n := p.Normal
+	return RowsM44(
+		V4{1 - 2*n.X*n.X, -2 * n.X * n.Y, -2 * n.X * n.Z, -2 * n.X * p.D},
+		V4{-2 * n.Y * n.X, 1 - 2*n.Y*n.Y, -2 * n.Y * n.Z, -2 * n.Y * p.D},
+		V4{-2 * n.Z * n.X, -2 * n.Z * n.Y, 1 - 2*n.Z*n.Z, -2 * n.Z * p.D},
+		V4{0, 0, 0, 1})
+}
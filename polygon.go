@@ -0,0 +1,152 @@
+package vector
+
+import "sort"
+
+// Polygon is a closed 2D polygon: the last point joins back to the first.
+// Counterclockwise is the positive winding.
+type Polygon []V2
+
+// SignedArea is positive if the polygon winds counterclockwise and
+// negative if it winds clockwise.
+func (poly Polygon) SignedArea() float64 {
+	var a float64
+	for i, p := range poly {
+		a += p.Cross(poly[(i+1)%len(poly)])
+	}
+	return a / 2
+}
+
+func (poly Polygon) Area() float64 {
+	a := poly.SignedArea()
+	if a < 0 {
+		return -a
+	}
+	return a
+}
+
+// CCW reports whether the polygon winds counterclockwise.
+func (poly Polygon) CCW() bool {
+	return poly.SignedArea() > 0
+}
+
+// Reverse returns the polygon with the winding flipped.
+func (poly Polygon) Reverse() Polygon {
+	r := make(Polygon, len(poly))
+	for i, p := range poly {
+		r[len(poly)-1-i] = p
+	}
+	return r
+}
+
+// Centroid returns the center of mass of the polygon's area.  If it has
+// no area, the average of the points is used instead.
+func (poly Polygon) Centroid() V2 {
+	var a float64
+	var c V2
+	for i, p := range poly {
+		q := poly[(i+1)%len(poly)]
+		cross := p.Cross(q)
+		a += cross
+		c = c.Add(p.Add(q).Scale(cross))
+	}
+	if a == 0 {
+		if len(poly) == 0 {
+			return V2{}
+		}
+		for _, p := range poly {
+			c = c.Add(p)
+		}
+		return c.Scale(1 / float64(len(poly)))
+	}
+	return c.Scale(1 / (3 * a))
+}
+
+// WindingNumber counts how many times the polygon goes counterclockwise
+// around p (clockwise counts negative).
+func (poly Polygon) WindingNumber(p V2) int {
+	w := 0
+	for i, a := range poly {
+		b := poly[(i+1)%len(poly)]
+		side := b.Sub(a).Cross(p.Sub(a))
+		if a.Y <= p.Y {
+			if b.Y > p.Y && side > 0 {
+				w++
+			}
+		} else if b.Y <= p.Y && side < 0 {
+			w--
+		}
+	}
+	return w
+}
+
+// ContainsNonZero tests p using the nonzero rule: p is inside if the
+// polygon winds around it at all.
+func (poly Polygon) ContainsNonZero(p V2) bool {
+	return poly.WindingNumber(p) != 0
+}
+
+// ContainsEvenOdd tests p using the even-odd rule: p is inside if a ray
+// from it crosses the polygon an odd number of times.
+func (poly Polygon) ContainsEvenOdd(p V2) bool {
+	in := false
+	for i, a := range poly {
+		b := poly[(i+1)%len(poly)]
+		if (a.Y > p.Y) != (b.Y > p.Y) && p.X < (b.X-a.X)*(p.Y-a.Y)/(b.Y-a.Y)+a.X {
+			in = !in
+		}
+	}
+	return in
+}
+
+// ConvexHull returns the smallest convex polygon containing all the points,
+// counterclockwise and without any collinear points, using Andrew's
+// monotone chain algorithm.
+func ConvexHull(points []V2) Polygon {
+	ps := make([]V2, len(points))
+	copy(ps, points)
+	sort.Slice(ps, func(i, j int) bool {
+		if ps[i].X != ps[j].X {
+			return ps[i].X < ps[j].X
+		}
+		return ps[i].Y < ps[j].Y
+	})
+
+	// drop duplicates
+	n := 0
+	for i, p := range ps {
+		if i == 0 || p != ps[n-1] {
+			ps[n] = p
+			n++
+		}
+	}
+	ps = ps[:n]
+
+	if len(ps) < 3 {
+		return Polygon(ps)
+	}
+
+	turn := func(o, a, b V2) float64 {
+		return a.Sub(o).Cross(b.Sub(o))
+	}
+
+	hull := make(Polygon, 0, 2*len(ps))
+
+	// lower hull, then upper hull
+	for _, p := range ps {
+		for len(hull) >= 2 && turn(hull[len(hull)-2], hull[len(hull)-1], p) <= 0 {
+			hull = hull[:len(hull)-1]
+		}
+		hull = append(hull, p)
+	}
+	lower := len(hull) + 1
+	for i := len(ps) - 2; i >= 0; i-- {
+		p := ps[i]
+		for len(hull) >= lower && turn(hull[len(hull)-2], hull[len(hull)-1], p) <= 0 {
+			hull = hull[:len(hull)-1]
+		}
+		hull = append(hull, p)
+	}
+
+	// the last point is the first one again
+	return hull[:len(hull)-1]
+}
@@ -58,36 +58,10 @@ func (q Q) Scale(s float64) Q {
 	return Q{q.R * s, q.I * s, q.J * s, q.K * s}
 }
 
-// Euler will try to return a set of 3 rotations about the x, y, and z axis.
-// x: bank
-// y: heading
-// z: attitude
-//r	i	j	k
-//w	x	y	z
+// Euler returns the rotations about X, then Y, then Z that make up the
+// same rotation as q.  See M33.EulerAngles.
 func (q Q) Euler() Euler {
-	gimbal := q.I*q.J + q.K*q.R
-	if gimbal > 0.499 {
-		return Euler{
-			0,
-			2 * Atan2(q.I, q.R),
-			math.Pi / 2}
-	}
-
-	if gimbal < -0.499 {
-		return Euler{
-			0,
-			-2 * Atan2(q.I, q.R),
-			-math.Pi / 2}
-	}
-
-	sqi := q.I * q.I
-	sqj := q.J * q.J
-	sqk := q.K * q.K
-
-	return Euler{
-		Atan2(2*q.I*q.R-2*q.J*q.K, 1-2*sqi-2*sqk),
-		Atan2(2*q.J*q.R-2*q.I*q.K, 1-2*sqj-2*sqk),
-		Asin(2 * gimbal)}
+	return q.Normalize().M33().Euler()
 }
 
 // M33 converts a quaternion to a 3x3 rotation matrix
@@ -119,3 +93,178 @@ func (q Q) M33() M33 {
 func (q Q) String() string {
 	return fmt.Sprintf("\t{%.4f,\t%.4f,\t%.4f,\t%.4f}", q.R, q.I, q.J, q.K)
 }
+
+// Dot returns the 4 dimensional dot product of two quaternions.
+// For unit quaternions this is the cosine of half the angle between them.
+func (a Q) Dot(b Q) float64 {
+	return a.R*b.R + a.I*b.I + a.J*b.J + a.K*b.K
+}
+
+func (q Q) LenSq() float64 {
+	return q.Dot(q)
+}
+
+func (q Q) Len() float64 {
+	return math.Sqrt(q.LenSq())
+}
+
+// Conjugate negates the imaginary part.  For a unit quaternion this
+// is the same as the inverse, and is a lot cheaper.
+func (q Q) Conjugate() Q {
+	return Q{q.R, -q.I, -q.J, -q.K}
+}
+
+// Inverse returns the multiplicative inverse of any non-zero quaternion.
+func (q Q) Inverse() Q {
+	l := q.LenSq()
+	if l == 0.0 {
+		return IdentityQ()
+	}
+	return q.Conjugate().Scale(1.0 / l)
+}
+
+// Angle returns the smallest angle needed to rotate from orientation a to b.
+// Both quaternions are assumed to be normalized.
+func (a Q) Angle(b Q) Radian {
+	d := math.Abs(a.Dot(b))
+	if d > 1.0 {
+		d = 1.0
+	}
+	return 2 * Acos(d)
+}
+
+// Log returns the natural logarithm of a quaternion.
+// For a unit quaternion the real part is zero and the imaginary part is
+// the rotation axis scaled by half the rotation angle.
+func (q Q) Log() Q {
+	l := q.Len()
+	if l == 0.0 {
+		return Q{}
+	}
+	vl := math.Sqrt(q.I*q.I + q.J*q.J + q.K*q.K)
+	if vl <= epsilon*l {
+		return Q{math.Log(l), 0, 0, 0}
+	}
+	// atan2 stays accurate for small angles, where acos(q.R / l) doesn't
+	s := math.Atan2(vl, q.R) / vl
+	return Q{math.Log(l), q.I * s, q.J * s, q.K * s}
+}
+
+// Exp is the inverse of Log.
+func (q Q) Exp() Q {
+	e := math.Exp(q.R)
+	vl := math.Sqrt(q.I*q.I + q.J*q.J + q.K*q.K)
+	if vl <= epsilon {
+		// sin(vl)/vl is 1 at this precision
+		return Q{e, q.I * e, q.J * e, q.K * e}
+	}
+	s := e * math.Sin(vl) / vl
+	return Q{e * math.Cos(vl), q.I * s, q.J * s, q.K * s}
+}
+
+// Pow raises a quaternion to a real power.  For a unit quaternion this
+// scales the rotation angle by t, keeping the same axis.
+func (q Q) Pow(t float64) Q {
+	return q.Log().Scale(t).Exp()
+}
+
+// Nlerp linearly interpolates between a and b and normalizes the result.
+// It takes the shortest path, and is cheaper than Slerp but does not
+// move at a constant angular velocity.
+func (a Q) Nlerp(b Q, t float64) Q {
+	if a.Dot(b) < 0 {
+		b = b.Scale(-1)
+	}
+	return a.Scale(1 - t).Add(b.Scale(t)).Normalize()
+}
+
+// Slerp spherically interpolates between orientations a and b at a constant
+// angular velocity, always taking the shortest arc.  Both quaternions
+// should be normalized.
+func (a Q) Slerp(b Q, t float64) Q {
+	if a.Dot(b) < 0 {
+		b = b.Scale(-1)
+	}
+	return a.slerp(b, t)
+}
+
+// slerp is Slerp without the shortest arc correction.  Squad needs this
+// so that it doesn't flip between the control points.
+func (a Q) slerp(b Q, t float64) Q {
+	d := a.Dot(b)
+
+	// nearly parallel: sin(θ) gets too small to divide by, and
+	// a plain lerp is indistinguishable anyway.
+	if math.Abs(d) > 0.9995 {
+		return a.Scale(1 - t).Add(b.Scale(t)).Normalize()
+	}
+
+	θ := math.Acos(d)
+	s := math.Sin(θ)
+	sa := math.Sin((1-t)*θ) / s
+	sb := math.Sin(t*θ) / s
+	return a.Scale(sa).Add(b.Scale(sb))
+}
+
+// Squad does spherical cubic interpolation between q1 and q2, using the
+// inner control points s1 and s2 (see SquadTangent).
+func Squad(q1, q2, s1, s2 Q, t float64) Q {
+	return q1.slerp(q2, t).slerp(s1.slerp(s2, t), 2*t*(1-t))
+}
+
+// SquadTangent returns the inner control point for key q, given its
+// neighbouring keys.  The keys should already be on the same hemisphere
+// (see SplineQ).
+func SquadTangent(prev, q, next Q) Q {
+	inv := q.Conjugate()
+	a := inv.Mult(next).Log()
+	b := inv.Mult(prev).Log()
+	return q.Mult(a.Add(b).Scale(-0.25).Exp())
+}
+
+// SplineQ smoothly interpolates through a sequence of key orientations
+// using Squad, with the tangents computed automatically.  t runs from 0 at
+// the first key to len(keys)-1 at the last key.
+func SplineQ(keys []Q, t float64) Q {
+	n := len(keys)
+	if n == 0 {
+		return IdentityQ()
+	}
+	if n == 1 || t <= 0 {
+		return keys[0]
+	}
+	if t >= float64(n-1) {
+		return keys[n-1]
+	}
+
+	i := int(t)
+	t -= float64(i)
+
+	// grab the 4 keys surrounding this segment, clamping at the ends,
+	// and flip them onto the same hemisphere as their neighbour (working
+	// outward from the start of the segment) so we always go the short
+	// way around.
+	var k [4]Q
+	for j := range k {
+		idx := i - 1 + j
+		if idx < 0 {
+			idx = 0
+		} else if idx > n-1 {
+			idx = n - 1
+		}
+		k[j] = keys[idx]
+	}
+	if k[0].Dot(k[1]) < 0 {
+		k[0] = k[0].Scale(-1)
+	}
+	if k[2].Dot(k[1]) < 0 {
+		k[2] = k[2].Scale(-1)
+	}
+	if k[3].Dot(k[2]) < 0 {
+		k[3] = k[3].Scale(-1)
+	}
+
+	s1 := SquadTangent(k[0], k[1], k[2])
+	s2 := SquadTangent(k[1], k[2], k[3])
+	return Squad(k[1], k[2], s1, s2, t)
+}
@@ -0,0 +1,155 @@
+package vector
+
+import (
+	"math"
+	"math/rand"
+)
+
+// Random sampling.  Everything here takes its randomness from lr, like
+// RandV3, so results are repeatable given a seed.  Most of the formulas are
+// from Physically Based Rendering (pbr-book.org).
+
+// RandUnitV3 returns a point uniformly distributed on the surface of the
+// unit sphere, which is also a random direction.
+func RandUnitV3(lr *rand.Rand) V3 {
+	z := 2*lr.Float64() - 1
+	r := math.Sqrt(math.Max(0, 1-z*z))
+	φ := τ * lr.Float64()
+	return V3{r * math.Cos(φ), r * math.Sin(φ), z}
+}
+
+// RandDiskV2 returns a point uniformly distributed in the unit disk, using
+// Shirley and Chiu's concentric mapping.  Nearby inputs stay nearby, which
+// keeps stratified samples stratified.
+func RandDiskV2(lr *rand.Rand) V2 {
+	return concentricDisk(lr.Float64(), lr.Float64())
+}
+
+func concentricDisk(u, v float64) V2 {
+	a := 2*u - 1
+	b := 2*v - 1
+	if a == 0 && b == 0 {
+		return V2{}
+	}
+
+	var r, φ float64
+	if math.Abs(a) > math.Abs(b) {
+		r = a
+		φ = π / 4 * (b / a)
+	} else {
+		r = b
+		φ = π/2 - π/4*(a/b)
+	}
+	return V2{r * math.Cos(φ), r * math.Sin(φ)}
+}
+
+// basis returns two unit vectors which, with n, make a right handed
+// orthonormal basis.  n must be normalized.  This is the branchless method
+// from Duff et al, "Building an Orthonormal Basis, Revisited".
+func basis(n V3) (V3, V3) {
+	sign := math.Copysign(1, n.Z)
+	a := -1 / (sign + n.Z)
+	b := n.X * n.Y * a
+	return V3{1 + sign*n.X*n.X*a, sign * b, -sign * n.X},
+		V3{b, sign + n.Y*n.Y*a, -n.Y}
+}
+
+// RandHemisphereV3 returns a direction on the side of normal (which should
+// be normalized) with a cosine weighted distribution, so directions close
+// to the normal are more likely.  The probability density is cos(θ)/π.
+func RandHemisphereV3(lr *rand.Rand, normal V3) V3 {
+	d := RandDiskV2(lr)
+	z := math.Sqrt(math.Max(0, 1-d.LenSq()))
+	t, b := basis(normal)
+	return t.Scale(d.X).Add(b.Scale(d.Y)).Add(normal.Scale(z))
+}
+
+// RandConeV3 returns a direction uniformly distributed within angle of
+// axis, which should be normalized.
+func RandConeV3(lr *rand.Rand, axis V3, angle Radian) V3 {
+	cθ := 1 - lr.Float64()*(1-Cos(angle))
+	sθ := math.Sqrt(math.Max(0, 1-cθ*cθ))
+	φ := τ * lr.Float64()
+	t, b := basis(axis)
+	return t.Scale(sθ * math.Cos(φ)).Add(b.Scale(sθ * math.Sin(φ))).Add(axis.Scale(cθ))
+}
+
+// RandQ returns a uniformly distributed random rotation, using Shoemake's
+// method from Graphics Gems III.
+func RandQ(lr *rand.Rand) Q {
+	u := lr.Float64()
+	a := τ * lr.Float64()
+	b := τ * lr.Float64()
+	s1 := math.Sqrt(1 - u)
+	s2 := math.Sqrt(u)
+	return Q{s2 * math.Cos(b), s1 * math.Sin(a), s1 * math.Cos(a), s2 * math.Sin(b)}
+}
+
+// RandPoint returns a point uniformly distributed inside the triangle.
+func (tri Triangle) RandPoint(lr *rand.Rand) V3 {
+	s := math.Sqrt(lr.Float64())
+	v := lr.Float64()
+	return tri.A.Scale(1 - s).Add(tri.B.Scale(s * (1 - v))).Add(tri.C.Scale(s * v))
+}
+
+// RandPoint returns a point uniformly distributed inside the box.
+func (b AABB3) RandPoint(lr *rand.Rand) V3 {
+	return b.Min.Add(b.Size().Mult(V3{lr.Float64(), lr.Float64(), lr.Float64()}))
+}
+
+// belowOne is the largest number less than 1.  Low discrepancy samples are
+// clamped to it, as rounding (particularly in float32) could make them 1.
+var belowOne = math.Nextafter(1, 0)
+
+// RadicalInverse mirrors the digits of i in base around the decimal point,
+// so 1, 2, 3, 4 in base 2 (1, 10, 11, 100) become 0.1, 0.01, 0.11, 0.001.
+// It's the building block of the Halton and Hammersley sequences.  It
+// panics if base is less than 2.
+func RadicalInverse(i uint32, base uint32) float64 {
+	if base < 2 {
+		panic("vector: RadicalInverse base must be at least 2")
+	}
+	var r float64
+	var f float64 = 1
+	b := float64(base)
+	for i > 0 {
+		f /= b
+		r += f * float64(i%base)
+		i /= base
+	}
+	return math.Min(r, belowOne)
+}
+
+// Halton returns the i-th point of the 2D Halton sequence, a low
+// discrepancy sequence in the unit square.  Start at 1: point 0 is 0, 0.
+func Halton(i uint32) V2 {
+	return V2{RadicalInverse(i, 2), RadicalInverse(i, 3)}
+}
+
+// Halton3 is the 3D Halton sequence, in the unit cube.
+func Halton3(i uint32) V3 {
+	return V3{RadicalInverse(i, 2), RadicalInverse(i, 3), RadicalInverse(i, 5)}
+}
+
+// Hammersley returns the i-th of n points of the Hammersley set.  Unlike
+// Halton you need to know in advance how many points you want, but they
+// are a bit more evenly spread.
+func Hammersley(i, n uint32) V2 {
+	return V2{float64(i) / float64(n), RadicalInverse(i, 2)}
+}
+
+// Sobol returns the i-th point of the first two dimensions of the Sobol
+// sequence.  Every power of two sized run of points starting at a multiple
+// of that power is stratified in both dimensions.
+func Sobol(i uint32) V2 {
+	var x, y uint32
+	for v, v2 := uint32(1<<31), uint32(1<<31); i != 0; i, v, v2 = i>>1, v>>1, v2^(v2>>1) {
+		if i&1 != 0 {
+			x ^= v
+			y ^= v2
+		}
+	}
+	return V2{
+		math.Min(float64(x)/(1<<32), belowOne),
+		math.Min(float64(y)/(1<<32), belowOne)}
+}
@@ -0,0 +1,188 @@
+package vector
+
+import "math"
+
+// Ray is a half line starting at Origin and heading off in Direction
+// forever.  Direction should be normalized so that hit distances come out
+// in world units.
+type Ray struct {
+	Origin    V3
+	Direction V3
+}
+
+// RayHit describes where a ray struck something.
+// Normal is the surface normal at Point, pointing out of the shape.
+type RayHit struct {
+	Dist   float64
+	Point  V3
+	Normal V3
+}
+
+// Ray returns a ray starting at l.Start and pointing through l.End.
+func (l Line) Ray() Ray {
+	return Ray{l.Start, l.End.Sub(l.Start).Normalize()}
+}
+
+// UnprojectRay returns the ray going into the screen through pixel ix, iy.
+func (cam *Camera) UnprojectRay(ix, iy float64) Ray {
+	p := cam.Unproject(ix, iy)
+	return Line{p[0], p[1]}.Ray()
+}
+
+// At returns the point at distance t along the ray.
+func (r Ray) At(t float64) V3 {
+	return r.Origin.Add(r.Direction.Scale(t))
+}
+
+func (r Ray) hit(t float64, n V3) RayHit {
+	return RayHit{t, r.At(t), n}
+}
+
+// IntersectSphere finds the first place the ray enters the sphere.  If the
+// ray starts inside, the hit is where it leaves.
+func (r Ray) IntersectSphere(s Sphere) (RayHit, bool) {
+	oc := r.Origin.Sub(s.Center)
+	b := oc.Dot(r.Direction)
+	c := oc.LenSq() - s.Radius*s.Radius
+
+	// starting outside and pointing away
+	if c > 0 && b > 0 {
+		return RayHit{}, false
+	}
+
+	disc := b*b - c
+	if disc < 0 {
+		return RayHit{}, false
+	}
+
+	sq := math.Sqrt(disc)
+	t := -b - sq
+	if t < 0 {
+		t = -b + sq
+	}
+
+	p := r.At(t)
+	return RayHit{t, p, p.Sub(s.Center).Normalize()}, true
+}
+
+// IntersectPlane hits the plane from either side.  The normal returned is
+// always the plane's normal.
+func (r Ray) IntersectPlane(p Plane) (RayHit, bool) {
+	denom := p.Normal.Dot(r.Direction)
+	if math.Abs(denom) <= 16*epsilon*p.Normal.Len()*r.Direction.Len() {
+		return RayHit{}, false
+	}
+
+	t := -(p.Normal.Dot(r.Origin) + p.D) / denom
+	if t < 0 {
+		return RayHit{}, false
+	}
+
+	return r.hit(t, p.Normal), true
+}
+
+// IntersectTriangle hits either side of a triangle using the
+// Möller–Trumbore algorithm.  bary is the barycentric coordinate of the hit
+// point, weighting A, B and C respectively.  The normal follows the
+// counterclockwise winding of A, B, C.
+func (r Ray) IntersectTriangle(tri Triangle) (hit RayHit, bary V3, ok bool) {
+	e1 := tri.B.Sub(tri.A)
+	e2 := tri.C.Sub(tri.A)
+
+	p := r.Direction.Cross(e2)
+	det := e1.Dot(p)
+	if math.Abs(det) <= 16*epsilon*e1.Len()*e2.Len()*r.Direction.Len() {
+		return
+	}
+	id := 1.0 / det
+
+	s := r.Origin.Sub(tri.A)
+	u := s.Dot(p) * id
+	if u < 0 || u > 1 {
+		return
+	}
+
+	q := s.Cross(e1)
+	v := r.Direction.Dot(q) * id
+	if v < 0 || u+v > 1 {
+		return
+	}
+
+	t := e2.Dot(q) * id
+	if t < 0 {
+		return
+	}
+
+	return r.hit(t, e1.Cross(e2).Normalize()), V3{1 - u - v, u, v}, true
+}
+
+// IntersectAABB3 uses the slab method to find where the ray enters the box.
+// If the ray starts inside, the hit is where it leaves.
+func (r Ray) IntersectAABB3(b AABB3) (RayHit, bool) {
+	o := [3]float64{r.Origin.X, r.Origin.Y, r.Origin.Z}
+	d := [3]float64{r.Direction.X, r.Direction.Y, r.Direction.Z}
+	min := [3]float64{b.Min.X, b.Min.Y, b.Min.Z}
+	max := [3]float64{b.Max.X, b.Max.Y, b.Max.Z}
+
+	tmin := math.Inf(-1)
+	tmax := math.Inf(1)
+	var nmin, nmax [3]float64
+
+	tolerance := 16 * epsilon * r.Direction.Len()
+	for i := 0; i < 3; i++ {
+		if math.Abs(d[i]) <= tolerance {
+			// parallel to this slab, so we had better be inside it
+			if o[i] < min[i] || o[i] > max[i] {
+				return RayHit{}, false
+			}
+			continue
+		}
+
+		id := 1.0 / d[i]
+		t1 := (min[i] - o[i]) * id
+		t2 := (max[i] - o[i]) * id
+		var s float64 = -1
+		if t1 > t2 {
+			t1, t2 = t2, t1
+			s = 1
+		}
+
+		if t1 > tmin {
+			tmin = t1
+			nmin = [3]float64{}
+			nmin[i] = s
+		}
+		if t2 < tmax {
+			tmax = t2
+			nmax = [3]float64{}
+			nmax[i] = -s
+		}
+		if tmin > tmax {
+			return RayHit{}, false
+		}
+	}
+
+	if tmax < 0 {
+		return RayHit{}, false
+	}
+	if tmin < 0 {
+		return r.hit(tmax, V3{nmax[0], nmax[1], nmax[2]}), true
+	}
+	return r.hit(tmin, V3{nmin[0], nmin[1], nmin[2]}), true
+}
+
+// IntersectOBB does the slab test in the box's own frame.
+func (r Ray) IntersectOBB(b OBB) (RayHit, bool) {
+	inv := b.Rotate.Transpose()
+	local := Ray{
+		inv.MultV3(r.Origin.Sub(b.Center)),
+		inv.MultV3(r.Direction),
+	}
+
+	hit, ok := local.IntersectAABB3(AABB3{b.HalfSize.Scale(-1), b.HalfSize})
+	if !ok {
+		return RayHit{}, false
+	}
+
+	return r.hit(hit.Dist, b.Rotate.MultV3(hit.Normal)), true
+}
@@ -0,0 +1,7 @@
+package vector
+
+// Sphere is a ball of Radius around Center.
+type Sphere struct {
+	Center V3
+	Radius float64
+}
@@ -0,0 +1,79 @@
+package vector
+
+// Transform is a scale, then a rotation, then a translation, kept as
+// separate parts so they can be edited and animated.  Rotation should be
+// normalized.
+//
+// Composing transforms with non-uniform scale and rotation can produce
+// shear, which a Transform can't hold.  Compose and Inverse are exact when
+// the scale is uniform; otherwise use M44 if you need the shear.
+type Transform struct {
+	Position V3
+	Rotation Q
+	Scale    V3
+}
+
+func IdentityTransform() Transform {
+	return Transform{Rotation: IdentityQ(), Scale: V3{1, 1, 1}}
+}
+
+// Compose returns the transform that applies child first, then t, the same
+// way t.M44().Mult(child.M44()) does.
+func (t Transform) Compose(child Transform) Transform {
+	return Transform{
+		Position: t.TransformPoint(child.Position),
+		Rotation: t.Rotation.Mult(child.Rotation).Normalize(),
+		Scale:    t.Scale.Mult(child.Scale),
+	}
+}
+
+// Inverse returns the transform that undoes t.
+func (t Transform) Inverse() Transform {
+	r := t.Rotation.Conjugate()
+	s := V3{1 / t.Scale.X, 1 / t.Scale.Y, 1 / t.Scale.Z}
+	return Transform{
+		Position: r.M33().MultV3(t.Position).Mult(s).Scale(-1),
+		Rotation: r,
+		Scale:    s,
+	}
+}
+
+// TransformPoint scales, rotates and translates a point.
+func (t Transform) TransformPoint(p V3) V3 {
+	return t.Rotation.M33().MultV3(p.Mult(t.Scale)).Add(t.Position)
+}
+
+// TransformDirection scales and rotates a direction, without translating it.
+func (t Transform) TransformDirection(v V3) V3 {
+	return t.Rotation.M33().MultV3(v.Mult(t.Scale))
+}
+
+func (t Transform) M44() M44 {
+	return t.M34().M44()
+}
+
+func (t Transform) M34() M34 {
+	m := t.Rotation.M33()
+	for i := 0; i < 3; i++ {
+		m[i] *= t.Scale.X
+		m[3+i] *= t.Scale.Y
+		m[6+i] *= t.Scale.Z
+	}
+	return RotateTranslateM34(m, t.Position)
+}
+
+// Transform breaks the matrix into a Transform using Decompose.  Any shear
+// or perspective is lost.
+func (m M44) Transform() Transform {
+	translate, rotate, scale, _, _ := m.Decompose()
+	return Transform{translate, rotate, scale}
+}
+
+// Lerp interpolates position and scale linearly and rotation with Slerp.
+func (a Transform) Lerp(b Transform, t float64) Transform {
+	return Transform{
+		Position: a.Position.Lerp(b.Position, t),
+		Rotation: a.Rotation.Slerp(b.Rotation, t),
+		Scale:    a.Scale.Lerp(b.Scale, t),
+	}
+}
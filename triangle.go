@@ -0,0 +1,7 @@
+package vector
+
+// Triangle is three points.  Counterclockwise winding (looking at the
+// front) is considered the front face.
+type Triangle struct {
+	A, B, C V3
+}
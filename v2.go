@@ -21,11 +21,15 @@ func (v V2) LenSq() float64 {
 func (v V2) Dot(a V2) float64 {
 	return v.X*a.X + v.Y*a.Y
 }
+
+// Cross returns the Z component of the 3D cross product of v and a with
+// Z = 0.  It's positive if a is counterclockwise from v.
 func (v V2) Cross(a V2) float64 {
-	// not sure if this is right.
-	// https://github.com/pgkelley4/line-segments-intersect/blob/master/js/line-segments-intersect.js
 	return v.X*a.Y - v.Y*a.X
 }
+func (v V2) Mult(a V2) V2 {
+	return V2{v.X * a.X, v.Y * a.Y}
+}
 func (v V2) Sub(a V2) V2 {
 	return V2{v.X - a.X, v.Y - a.Y}
 }
@@ -43,6 +47,81 @@ func (v V2) Normalize() V2 {
 	return v.Scale(1.0 / l)
 }
 
+// Min returns the smallest of each component.
+func (v V2) Min(a V2) V2 {
+	return V2{math.Min(v.X, a.X), math.Min(v.Y, a.Y)}
+}
+
+// Max returns the largest of each component.
+func (v V2) Max(a V2) V2 {
+	return V2{math.Max(v.X, a.X), math.Max(v.Y, a.Y)}
+}
+
+// Abs returns the absolute value of each component.
+func (v V2) Abs() V2 {
+	return V2{math.Abs(v.X), math.Abs(v.Y)}
+}
+
+// Clamp limits each component to the range min to max.
+func (v V2) Clamp(min, max V2) V2 {
+	return v.Max(min).Min(max)
+}
+
+// Lerp linearly interpolates from v at t = 0 to a at t = 1.
+func (v V2) Lerp(a V2, t float64) V2 {
+	return a.Sub(v).Scale(t).Add(v)
+}
+
+// Perp returns v rotated 90° counterclockwise.
+func (v V2) Perp() V2 {
+	return V2{-v.Y, v.X}
+}
+
+// Angle returns the angle of v counterclockwise from the X axis, in the
+// range -π to π.
+func (v V2) Angle() Radian {
+	return Atan2(v.Y, v.X)
+}
+
+// AngleTo returns how far counterclockwise a is from v, in the range -π
+// to π.
+func (v V2) AngleTo(a V2) Radian {
+	return Atan2(v.Cross(a), v.Dot(a))
+}
+
+// Rotate turns v counterclockwise by φ.
+func (v V2) Rotate(φ Radian) V2 {
+	s := Sin(φ)
+	c := Cos(φ)
+	return V2{v.X*c - v.Y*s, v.X*s + v.Y*c}
+}
+
+// Reflect a direction vector with normal vector
+func (v V2) Reflect(n V2) V2 {
+	return v.Sub(n.Scale(2 * v.Dot(n)))
+}
+
+// Project returns the part of v pointing along a.
+func (v V2) Project(a V2) V2 {
+	l := a.LenSq()
+	if l == 0.0 {
+		return V2{}
+	}
+	return a.Scale(v.Dot(a) / l)
+}
+
+// V3 extends v with a Z component.
+func (v V2) V3(z float64) V3 {
+	return V3{v.X, v.Y, z}
+}
+
 func (v V2) String() string {
 	return fmt.Sprintf("%.2f %.2f", v.X, v.Y)
 }
+
+// Eq does floating point ==, so is only suitable for
+// comparing to 0,0 or 1,1 etc.
+// Use ApproxEqual for anything computed.
+func (v V2) Eq(a V2) bool {
+	return v.X == a.X && v.Y == a.Y
+}
@@ -74,12 +74,48 @@ func (v V3) SubS(s float64) V3 {
 	return V3{v.X - s, v.Y - s, v.Z - s}
 }
 
+// Min returns the smallest of each component.
+func (v V3) Min(a V3) V3 {
+	return V3{math.Min(v.X, a.X), math.Min(v.Y, a.Y), math.Min(v.Z, a.Z)}
+}
+
+// Max returns the largest of each component.
+func (v V3) Max(a V3) V3 {
+	return V3{math.Max(v.X, a.X), math.Max(v.Y, a.Y), math.Max(v.Z, a.Z)}
+}
+
+// Abs returns the absolute value of each component.
+func (v V3) Abs() V3 {
+	return V3{math.Abs(v.X), math.Abs(v.Y), math.Abs(v.Z)}
+}
+
+// Clamp limits each component to the range min to max.
+func (v V3) Clamp(min, max V3) V3 {
+	return v.Max(min).Min(max)
+}
+
+// Lerp linearly interpolates from v at t = 0 to a at t = 1.
+func (v V3) Lerp(a V3, t float64) V3 {
+	return a.Sub(v).Scale(t).Add(v)
+}
+
+// XY drops the Z component.
+func (v V3) XY() V2 {
+	return V2{v.X, v.Y}
+}
+
+// V4 extends v with a W component.  See also CartesianToHomogeneous.
+func (v V3) V4(w float64) V4 {
+	return V4{v.X, v.Y, v.Z, w}
+}
+
 func (v V3) String() string {
 	return fmt.Sprintf("\t{   %.4f,   \t%.4f,   \t%.4f}", v.X, v.Y, v.Z)
 }
 
 // Eq does floating point ==, so is only suitable for
-// comparing to 0,0,0 or 1,1,1 etc
+// comparing to 0,0,0 or 1,1,1 etc.
+// Use ApproxEqual for anything computed.
 func (v V3) Eq(a V3) bool {
 	if v.X == a.X && v.Y == a.Y && v.Z == a.Z {
 		return true
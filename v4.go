@@ -1,10 +1,109 @@
 package vector
 
+import (
+	"fmt"
+	"math"
+)
+
 // V4 is a 4 component vector (x, y, z, and w usually)
 type V4 struct {
 	X, Y, Z, W float64
 }
 
+func (v V4) LenSq() float64 {
+	return v.Dot(v)
+}
+
+func (v V4) Len() float64 {
+	return math.Sqrt(v.LenSq())
+}
+
+func (v V4) Dist(a V4) float64 {
+	return v.Sub(a).Len()
+}
+
+func (v V4) Dot(a V4) float64 {
+	return v.X*a.X + v.Y*a.Y + v.Z*a.Z + v.W*a.W
+}
+
+func (v V4) Normalize() V4 {
+	l := v.Len()
+	if l == 0.0 {
+		return V4{}
+	}
+	return v.Scale(1.0 / l)
+}
+
+func (v V4) Mult(a V4) V4 {
+	return V4{v.X * a.X, v.Y * a.Y, v.Z * a.Z, v.W * a.W}
+}
+
+func (v V4) Scale(s float64) V4 {
+	return V4{v.X * s, v.Y * s, v.Z * s, v.W * s}
+}
+
+func (v V4) Add(a V4) V4 {
+	return V4{v.X + a.X, v.Y + a.Y, v.Z + a.Z, v.W + a.W}
+}
+
+func (v V4) AddS(s float64) V4 {
+	return V4{v.X + s, v.Y + s, v.Z + s, v.W + s}
+}
+
+func (v V4) Sub(a V4) V4 {
+	return V4{v.X - a.X, v.Y - a.Y, v.Z - a.Z, v.W - a.W}
+}
+
+func (v V4) SubS(s float64) V4 {
+	return V4{v.X - s, v.Y - s, v.Z - s, v.W - s}
+}
+
+// Min returns the smallest of each component.
+func (v V4) Min(a V4) V4 {
+	return V4{math.Min(v.X, a.X), math.Min(v.Y, a.Y), math.Min(v.Z, a.Z), math.Min(v.W, a.W)}
+}
+
+// Max returns the largest of each component.
+func (v V4) Max(a V4) V4 {
+	return V4{math.Max(v.X, a.X), math.Max(v.Y, a.Y), math.Max(v.Z, a.Z), math.Max(v.W, a.W)}
+}
+
+// Abs returns the absolute value of each component.
+func (v V4) Abs() V4 {
+	return V4{math.Abs(v.X), math.Abs(v.Y), math.Abs(v.Z), math.Abs(v.W)}
+}
+
+// Clamp limits each component to the range min to max.
+func (v V4) Clamp(min, max V4) V4 {
+	return v.Max(min).Min(max)
+}
+
+// Lerp linearly interpolates from v at t = 0 to a at t = 1.
+func (v V4) Lerp(a V4, t float64) V4 {
+	return a.Sub(v).Scale(t).Add(v)
+}
+
+// XYZ drops the W component.  See also HomogeneousToCartesian.
+func (v V4) XYZ() V3 {
+	return V3{v.X, v.Y, v.Z}
+}
+
+// XY drops the Z and W components.
+func (v V4) XY() V2 {
+	return V2{v.X, v.Y}
+}
+
+func (v V4) String() string {
+	return fmt.Sprintf("\t{   %.4f,   \t%.4f,   \t%.4f,   \t%.4f}", v.X, v.Y, v.Z, v.W)
+}
+
+// Eq does floating point ==, so is only suitable for
+// comparing to 0,0,0,0 or 1,1,1,1 etc.
+// Use ApproxEqual for anything computed.
+func (v V4) Eq(a V4) bool {
+	return v.X == a.X && v.Y == a.Y && v.Z == a.Z && v.W == a.W
+}
+
 func (v V4) HomogeneousToCartesian() V3 {
 	if v.W == 0.0 {
 		return V3{}
@@ -0,0 +1,7 @@
+// Package vector32 is a float32 copy of package vector, for building data
+// that gets handed straight to the GPU.  It has the same types with the
+// same methods.
+//
+// Everything except this file is generated from package vector by
+// gen32.go; make changes there and run go generate.
+package vector32
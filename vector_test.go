@@ -1,42 +1,34 @@
 package vector
 
 import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"image/color"
 	"math"
+	"math/rand"
+	"os"
+	"os/exec"
+	"path/filepath"
 	"testing"
+
+	"github.com/yobert/vector/vector32"
 )
 
 var _precision = 0.00001
 
-func feq(a, b float64) bool {
-	delta := a - b
-	if math.Abs(delta) < _precision {
-		return true
-	}
-	return false
-}
-func fne(a, b float64) bool {
-	return !feq(a, b)
-}
-func v3eq(a, b V3) bool {
-	if feq(a.X, b.X) && feq(a.Y, b.Y) && feq(a.Z, b.Z) {
-		return true
-	}
-	return false
-}
-func m33eq(a, b M33) bool {
-	for i := 0; i < 9; i++ {
-		if fne(a[i], b[i]) {
-			return false
-		}
-	}
-	return true
-}
+func feq(a, b float64) bool { return ApproxEqual(a, b, _precision) }
+func fne(a, b float64) bool { return !feq(a, b) }
+func v2eq(a, b V2) bool     { return a.ApproxEqual(b, _precision) }
+func v3eq(a, b V3) bool     { return a.ApproxEqual(b, _precision) }
+func m33eq(a, b M33) bool   { return a.ApproxEqual(b, _precision) }
+
+// qeq compares quaternions component by component, for testing the
+// algebra.  roteq is for when any quaternion for the same rotation will do.
 func qeq(a, b Q) bool {
-	if feq(a.R, b.R) && feq(a.I, b.I) && feq(a.J, b.J) && feq(a.K, b.K) {
-		return true
-	}
-	return false
+	return feq(a.R, b.R) && feq(a.I, b.I) && feq(a.J, b.J) && feq(a.K, b.K)
 }
+func roteq(a, b Q) bool { return a.ApproxEqual(b, _precision) }
 
 func TestDegree(t *testing.T) {
 	_precision = 0.0001
@@ -172,3 +164,1963 @@ func TestRadian(t *testing.T) {
 		t.Error("Degree->Radian")
 	}
 }
+
+func TestQSlerp(t *testing.T) {
+	_precision = 0.00001
+
+	a := AxisAngleQ(V3{0, 0, 1}, 0.3)
+	b := AxisAngleQ(V3{1, 2, 3}.Normalize(), 2.5)
+	total := a.Angle(b)
+
+	if !roteq(a.Slerp(b, 0), a) || !roteq(a.Slerp(b, 1), b) {
+		t.Error("Q Slerp() endpoints")
+	}
+
+	// constant angular velocity and unit length over a dense sweep
+	steps := 1000
+	prev := a
+	for i := 1; i <= steps; i++ {
+		q := a.Slerp(b, float64(i)/float64(steps))
+		if fne(q.Len(), 1) {
+			t.Fatalf("Q Slerp() not unit length at step %d: %v", i, q.Len())
+		}
+		if fne(float64(prev.Angle(q)), float64(total)/float64(steps)) {
+			t.Fatalf("Q Slerp() angular velocity not constant at step %d", i)
+		}
+		prev = q
+	}
+
+	// shortest arc: -b is the same orientation, so the path must be the same
+	if !v3eq(a.Slerp(b.Scale(-1), 0.5).M33().MultV3(V3{1, 0, 0}),
+		a.Slerp(b, 0.5).M33().MultV3(V3{1, 0, 0})) {
+		t.Error("Q Slerp() shortest arc")
+	}
+
+	// near parallel fallback must not blow up
+	c := AxisAngleQ(V3{0, 0, 1}, 0.3+1e-9)
+	if q := a.Slerp(c, 0.5); math.IsNaN(q.R) || fne(q.Len(), 1) {
+		t.Error("Q Slerp() near parallel")
+	}
+
+	for i := 0; i <= steps; i++ {
+		if q := a.Nlerp(b, float64(i)/float64(steps)); fne(q.Len(), 1) {
+			t.Fatalf("Q Nlerp() not unit length at step %d", i)
+		}
+	}
+}
+
+func TestQLogExpPow(t *testing.T) {
+	_precision = 0.00001
+
+	q := AxisAngleQ(V3{0, 1, 0}, 1.2)
+	if !qeq(q.Log().Exp(), q) {
+		t.Error("Q Log() Exp()")
+	}
+
+	// small angles keep their axis and size, even in float32
+	small := AxisAngleQ(V3{1, 0, 0}, 1e-4)
+	if l := small.Log(); !feq(l.I/5e-5, 1) {
+		t.Error("Q Log() small angle", l)
+	}
+	if l := small.Float32().Log(); math.Abs(float64(l.I)/5e-5-1) > 1e-3 {
+		t.Error("float32 Q Log() small angle", l)
+	}
+	if !qeq(q.Pow(0.5), AxisAngleQ(V3{0, 1, 0}, 0.6)) {
+		t.Error("Q Pow()")
+	}
+	if !qeq(q.Pow(0.5), IdentityQ().Slerp(q, 0.5)) {
+		t.Error("Q Pow() vs Slerp()")
+	}
+	if !qeq(q.Mult(q.Inverse()), IdentityQ()) {
+		t.Error("Q Inverse()")
+	}
+}
+
+func TestSplineQ(t *testing.T) {
+	_precision = 0.00001
+
+	keys := []Q{
+		IdentityQ(),
+		AxisAngleQ(V3{0, 0, 1}, 1),
+		AxisAngleQ(V3{0, 1, 0}, 1).Scale(-1),
+		AxisAngleQ(V3{1, 0, 0}, 2),
+	}
+
+	for i, k := range keys {
+		if k.Angle(SplineQ(keys, float64(i))) > 0.00001 {
+			t.Errorf("SplineQ() does not pass through key %d", i)
+		}
+	}
+
+	steps := 3000
+	prev := keys[0]
+	for i := 1; i <= steps; i++ {
+		q := SplineQ(keys, 3*float64(i)/float64(steps))
+		if fne(q.Len(), 1) {
+			t.Fatalf("SplineQ() not unit length at step %d", i)
+		}
+		if prev.Angle(q) > 0.01 {
+			t.Fatalf("SplineQ() jumps at step %d", i)
+		}
+		prev = q
+	}
+}
+
+func m44eq(a, b M44) bool { return a.ApproxEqual(b, _precision) }
+
+func TestM44Decompose(t *testing.T) {
+	_precision = 0.00001
+
+	translate := V3{1, -2, 3}
+	rotate := AxisAngleQ(V3{1, 2, -1}.Normalize(), 0.7)
+	scale := V3{2, 0.5, 3}
+	shear := V3{0.3, -0.2, 0.1}
+
+	m := TranslateM44(translate).Mult(rotate.M33().M44()).Mult(ScaleM44(scale))
+	tr, r, s, h, p := m.Decompose()
+	if !v3eq(tr, translate) || r.Angle(rotate) > 0.00001 || !v3eq(s, scale) ||
+		!v3eq(h, V3{}) || p != (V4{0, 0, 0, 1}) {
+		t.Error("M44 Decompose() TRS", tr, r, s, h, p)
+	}
+	if !v3eq(m.ScalePart(), scale) {
+		t.Error("M44 ScalePart() with rotation")
+	}
+
+	// mirrored
+	m = ComposeM44(translate, rotate, V3{-2, 0.5, 3}, shear, V4{0, 0, 0, 1})
+	tr, r, s, h, p = m.Decompose()
+	if !v3eq(s, V3{-2, 0.5, 3}) || r.Angle(rotate) > 0.00001 || !v3eq(h, shear) {
+		t.Error("M44 Decompose() negative determinant", s, h)
+	}
+	if !m44eq(ComposeM44(tr, r, s, h, p), m) {
+		t.Error("M44 Decompose() ComposeM44() mirrored round trip")
+	}
+
+	// with a projection on the front
+	persp := PerspectiveFrustum(60, 1.5, 1, 100).M44()
+	m = persp.Mult(ComposeM44(translate, rotate, scale, shear, V4{0, 0, 0, 1}))
+	if !m44eq(ComposeM44(m.Decompose()), m) {
+		t.Error("M44 Decompose() ComposeM44() perspective round trip")
+	}
+}
+
+func TestRay(t *testing.T) {
+	_precision = 0.00001
+
+	// A ray built to run along a plane or triangle is only parallel up to
+	// rounding, which has to count as parallel at either precision.
+	for _, n := range []V3{{0.3, -0.7, 0.2}, {2, -3, 5}} {
+		n = n.Normalize()
+		u := n.Cross(V3{0, 0, 1}).Normalize()
+		w := n.Cross(u)
+		plane := PointNormalPlane(V3{}, n)
+		tri := Triangle{u.Scale(-5), u.Scale(5), w.Scale(5)}
+		along := Ray{n.Scale(1e-3), u.Add(w).Normalize()}
+
+		if _, ok := along.IntersectPlane(plane); ok {
+			t.Error("Ray IntersectPlane() nearly parallel", n)
+		}
+		if _, _, ok := along.IntersectTriangle(tri); ok {
+			t.Error("Ray IntersectTriangle() nearly parallel", n)
+		}
+
+		n32, u32, w32 := n.Float32(), u.Float32(), w.Float32()
+		plane32 := vector32.PointNormalPlane(vector32.V3{}, n32)
+		tri32 := vector32.Triangle{A: u32.Scale(-5), B: u32.Scale(5), C: w32.Scale(5)}
+		along32 := vector32.Ray{Origin: n32.Scale(1e-3), Direction: u32.Add(w32).Normalize()}
+
+		if hit, ok := along32.IntersectPlane(plane32); ok {
+			t.Error("float32 Ray IntersectPlane() nearly parallel", n, hit.Dist)
+		}
+		if hit, _, ok := along32.IntersectTriangle(tri32); ok {
+			t.Error("float32 Ray IntersectTriangle() nearly parallel", n, hit.Dist)
+		}
+
+		// the box's local axes are u, w and n, so the ray runs along a face
+		obb32 := vector32.OBB{
+			Center:   n32.Scale(-1),
+			Rotate:   vector32.M33{u32.X, u32.Y, u32.Z, w32.X, w32.Y, w32.Z, n32.X, n32.Y, n32.Z},
+			HalfSize: vector32.V3{X: 1, Y: 1, Z: 1},
+		}
+		outside := vector32.Ray{Origin: n32.Scale(1e-3).Sub(u32.Scale(10)), Direction: u32}
+		if hit, ok := outside.IntersectOBB(obb32); ok {
+			t.Error("float32 Ray IntersectOBB() grazing miss", n, hit.Dist)
+		}
+	}
+
+	r := Line{V3{0, 0, -10}, V3{0, 0, -5}}.Ray()
+	if !v3eq(r.Direction, V3{0, 0, 1}) {
+		t.Error("Line Ray()")
+	}
+
+	hit, ok := r.IntersectSphere(Sphere{V3{0, 0, 0}, 2})
+	if !ok || fne(hit.Dist, 8) || !v3eq(hit.Point, V3{0, 0, -2}) || !v3eq(hit.Normal, V3{0, 0, -1}) {
+		t.Error("Ray IntersectSphere()", hit)
+	}
+	hit, ok = Ray{V3{}, V3{1, 0, 0}}.IntersectSphere(Sphere{V3{}, 2})
+	if !ok || fne(hit.Dist, 2) || !v3eq(hit.Normal, V3{1, 0, 0}) {
+		t.Error("Ray IntersectSphere() from inside", hit)
+	}
+	if _, ok = r.IntersectSphere(Sphere{V3{0, 3, 0}, 2}); ok {
+		t.Error("Ray IntersectSphere() miss")
+	}
+	if _, ok = r.IntersectSphere(Sphere{V3{0, 0, -20}, 2}); ok {
+		t.Error("Ray IntersectSphere() behind")
+	}
+
+	hit, ok = r.IntersectPlane(Plane{V3{0, 0, 1}, -3})
+	if !ok || fne(hit.Dist, 13) || !v3eq(hit.Point, V3{0, 0, 3}) {
+		t.Error("Ray IntersectPlane()", hit)
+	}
+	if _, ok = r.IntersectPlane(Plane{V3{1, 0, 0}, 0}); ok {
+		t.Error("Ray IntersectPlane() parallel")
+	}
+
+	tri := Triangle{V3{-1, -1, 0}, V3{3, -1, 0}, V3{-1, 3, 0}}
+	hit, bary, ok := r.IntersectTriangle(tri)
+	if !ok || fne(hit.Dist, 10) || !v3eq(hit.Normal, V3{0, 0, 1}) || !v3eq(bary, V3{0.5, 0.25, 0.25}) {
+		t.Error("Ray IntersectTriangle()", hit, bary)
+	}
+	if _, _, ok = (Ray{V3{2, 2, -1}, V3{0, 0, 1}}).IntersectTriangle(tri); ok {
+		t.Error("Ray IntersectTriangle() miss")
+	}
+
+	box := AABB3{V3{-1, -2, -3}, V3{1, 2, 3}}
+	hit, ok = r.IntersectAABB3(box)
+	if !ok || fne(hit.Dist, 7) || !v3eq(hit.Normal, V3{0, 0, -1}) {
+		t.Error("Ray IntersectAABB3()", hit)
+	}
+	hit, ok = Ray{V3{}, V3{0, -1, 0}}.IntersectAABB3(box)
+	if !ok || fne(hit.Dist, 2) || !v3eq(hit.Normal, V3{0, -1, 0}) {
+		t.Error("Ray IntersectAABB3() from inside", hit)
+	}
+	if _, ok = (Ray{V3{2, 0, -10}, V3{0, 0, 1}}).IntersectAABB3(box); ok {
+		t.Error("Ray IntersectAABB3() parallel miss")
+	}
+
+	obb := OBB{V3{0, 0, 5}, RotateAxisM33(V3{0, 0, 1}, τ/8), V3{1, 1, 1}}
+	hit, ok = Ray{V3{-10, 0, 5}, V3{1, 0, 0}}.IntersectOBB(obb)
+	if !ok || fne(hit.Dist, 10-math.Sqrt2) || !v3eq(hit.Point, V3{-math.Sqrt2, 0, 5}) {
+		t.Error("Ray IntersectOBB()", hit)
+	}
+	if !v3eq(hit.Normal, V3{-1, 1, 0}.Normalize()) && !v3eq(hit.Normal, V3{-1, -1, 0}.Normalize()) {
+		t.Error("Ray IntersectOBB() normal", hit.Normal)
+	}
+}
+
+func TestFrustumPlanes(t *testing.T) {
+	cam := Camera{Width: 800, Height: 600, YFov: 90, Near: 1, Far: 100}
+	cam.Position = V3{0, 0, 10}
+	cam.SetupViewProjection()
+	cam.SetupModelView()
+
+	f := cam.FrustumPlanes()
+
+	points := []struct {
+		p V3
+		v Visibility
+	}{
+		{V3{0, 0, 0}, Inside},
+		{V3{0, 0, 11}, Outside},  // behind
+		{V3{0, 0, 9.5}, Outside}, // before near
+		{V3{0, 0, -89}, Inside},
+		{V3{0, 0, -91}, Outside}, // past far
+		{V3{0, 9, 0}, Inside},
+		{V3{0, 11, 0}, Outside},
+		{V3{13, 0, 0}, Inside},
+		{V3{14, 0, 0}, Outside},
+	}
+	for _, c := range points {
+		if v := f.ClassifyPoint(c.p); v != c.v {
+			t.Errorf("FrustumPlanes ClassifyPoint(%v) = %v, want %v", c.p, v, c.v)
+		}
+	}
+
+	spheres := []struct {
+		s Sphere
+		v Visibility
+	}{
+		{Sphere{V3{0, 0, 0}, 1}, Inside},
+		{Sphere{V3{0, 10, 0}, 1}, Intersecting},
+		{Sphere{V3{0, 20, 0}, 1}, Outside},
+		{Sphere{V3{0, 0, 20}, 5}, Outside},
+	}
+	for _, c := range spheres {
+		if v := f.ClassifySphere(c.s); v != c.v {
+			t.Errorf("FrustumPlanes ClassifySphere(%v) = %v, want %v", c.s, v, c.v)
+		}
+	}
+
+	boxes := []struct {
+		b AABB3
+		v Visibility
+	}{
+		{AABB3{V3{-1, -1, -1}, V3{1, 1, 1}}, Inside},
+		{AABB3{V3{-1, 9, -1}, V3{1, 11, 1}}, Intersecting},
+		{AABB3{V3{-1, 19, -1}, V3{1, 21, 1}}, Outside},
+		{AABB3{V3{-100, -100, -50}, V3{100, 100, -40}}, Intersecting},
+		{AABB3{V3{-1, -1, 11}, V3{1, 1, 12}}, Outside},
+	}
+	for _, c := range boxes {
+		if v := f.ClassifyAABB3(c.b); v != c.v {
+			t.Errorf("FrustumPlanes ClassifyAABB3(%v) = %v, want %v", c.b, v, c.v)
+		}
+	}
+}
+
+func TestFloat32(t *testing.T) {
+	_precision = 0.0001
+
+	v := V3{1, 2, 3}
+	if !v3eq(V3FromFloat32(v.Float32()), v) {
+		t.Error("V3 Float32() round trip")
+	}
+
+	q := AxisAngleQ(V3{0, 0, 1}, -τ/4)
+	m := q.M33().M44().Float32()
+	if !v3eq(V3FromFloat32(m.MultV3(vector32.V3{X: 1, Y: 1, Z: 0})), V3{1, -1, 0}) {
+		t.Error("M44 Float32() MultV3()")
+	}
+	if !roteq(QFromFloat32(q.Float32().Slerp(vector32.IdentityQ(), 0.5)), q.Slerp(IdentityQ(), 0.5)) {
+		t.Error("Q Float32() Slerp()")
+	}
+
+	// The plane and ray intersection tests check their parallel limits in
+	// float32 too.  These are the other places where float32 needs its own
+	// limits rather than float64's.
+
+	// a condition number of about 1e6 is fine in float64, but is most of
+	// float32's precision
+	w := RotateQM34(AxisAngleQ(V3{1, 2, 3}.Normalize(), 0.7), V3{1, 2, 3}).M44()
+	thin := w.Mult(ScaleM44(V3{1, 1, 1e-6}))
+	if _, ok := thin.InverseOK(); !ok {
+		t.Error("M44 InverseOK() rejected a usable float64 matrix", thin.Cond())
+	}
+	if _, ok := thin.Float32().InverseOK(); ok {
+		t.Error("float32 M44 InverseOK() accepted a near singular matrix")
+	}
+	inv, ok := w.Float32().InverseOK()
+	if !ok || !M44FromFloat32(inv.Mult(w.Float32())).ApproxEqual(IdentityM44(), 1e-5) {
+		t.Error("float32 M44 InverseOK()", ok)
+	}
+	if _, ok := thin.M33().Float32().InverseOK(); ok {
+		t.Error("float32 M33 InverseOK() accepted a near singular matrix")
+	}
+
+	// Euler angles near and at gimbal lock still rebuild the same rotation
+	for _, b := range []Radian{π/2 - 1e-3, π/2 - 1e-5, π / 2, -π / 2} {
+		for _, order := range []RotationOrder{XYZ, ZYX, YXZ, ZXZ} {
+			e := EulerAngles{order, false, 0.3, b, -0.8}
+			if order == ZXZ {
+				e.B = b + π/2
+			}
+			m := e.M33().Float32()
+			got := m.EulerAngles(vector32.RotationOrder(order), false).M33()
+			if !M33FromFloat32(got).ApproxEqual(M33FromFloat32(m), 1e-5) {
+				t.Error("float32 M33 EulerAngles() near gimbal lock", order, b)
+			}
+		}
+	}
+}
+
+// TestGenerated checks vector32 matches what gen32.go makes from the
+// current source, so forgetting to run go generate fails the tests.
+func TestGenerated(t *testing.T) {
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go command not found")
+	}
+
+	dir := t.TempDir()
+	var stderr bytes.Buffer
+	cmd := exec.Command("go", "run", "gen32.go", "-o", dir)
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		t.Fatalf("gen32.go: %v\n%s", err, stderr.Bytes())
+	}
+	if stderr.Len() > 0 {
+		t.Errorf("gen32.go warnings:\n%s", stderr.Bytes())
+	}
+
+	want, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range want {
+		a, err := os.ReadFile(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, err := os.ReadFile(filepath.Join("vector32", filepath.Base(name)))
+		if err != nil || !bytes.Equal(a, b) {
+			t.Errorf("vector32/%s is out of date; run go generate", filepath.Base(name))
+		}
+	}
+
+	// and nothing generated is left over from a removed file
+	have, err := filepath.Glob(filepath.Join("vector32", "*.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range have {
+		b, err := os.ReadFile(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.HasPrefix(b, []byte("// Code generated by gen32.go")) {
+			continue
+		}
+		if _, err := os.Stat(filepath.Join(dir, filepath.Base(name))); err != nil {
+			t.Errorf("%s is stale; run go generate", name)
+		}
+	}
+}
+
+func TestAABB3(t *testing.T) {
+	_precision = 0.00001
+
+	b := PointsAABB3([]V3{{1, 2, 3}, {-1, 0, 5}, {0, 4, 4}})
+	if !v3eq(b.Min, V3{-1, 0, 3}) || !v3eq(b.Max, V3{1, 4, 5}) {
+		t.Error("PointsAABB3()", b)
+	}
+	if !EmptyAABB3().IsEmpty() || b.IsEmpty() || !PointsAABB3(nil).IsEmpty() {
+		t.Error("AABB3 IsEmpty()")
+	}
+	if !v3eq(b.Center(), V3{0, 2, 4}) || !v3eq(b.Extents(), V3{1, 2, 1}) {
+		t.Error("AABB3 Center() Extents()")
+	}
+	if fne(b.Volume(), 16) || fne(b.SurfaceArea(), 40) {
+		t.Error("AABB3 Volume() SurfaceArea()")
+	}
+	if !b.Contains(V3{0, 0, 3}) || b.Contains(V3{0, 5, 4}) {
+		t.Error("AABB3 Contains()")
+	}
+
+	a := AABB3{V3{0, 3, 4}, V3{2, 6, 7}}
+	if !b.Overlaps(a) || b.Overlaps(AABB3{V3{2, 2, 2}, V3{3, 3, 3}}) {
+		t.Error("AABB3 Overlaps()")
+	}
+	if u := b.Union(a); !v3eq(u.Min, V3{-1, 0, 3}) || !v3eq(u.Max, V3{2, 6, 7}) || !u.ContainsAABB3(a) {
+		t.Error("AABB3 Union()", u)
+	}
+	if i := b.Intersection(a); !v3eq(i.Min, V3{0, 3, 4}) || !v3eq(i.Max, V3{1, 4, 5}) {
+		t.Error("AABB3 Intersection()", i)
+	}
+	if !b.Intersection(AABB3{V3{5, 5, 5}, V3{6, 6, 6}}).IsEmpty() {
+		t.Error("AABB3 Intersection() disjoint")
+	}
+	if !v3eq(b.ClosestPoint(V3{5, 2, 0}), V3{1, 2, 3}) || !v3eq(b.ClosestPoint(V3{0, 1, 4}), V3{0, 1, 4}) {
+		t.Error("AABB3 ClosestPoint()")
+	}
+
+	// compare against transforming all of the corners
+	m := TranslateM44(V3{1, 2, 3}).Mult(RotateAxisM33(V3{1, 1, 0}, 0.8).M44()).Mult(ScaleM44(V3{2, -1, 1}))
+	var corners []V3
+	for i := 0; i < 8; i++ {
+		c := b.Min
+		if i&1 != 0 {
+			c.X = b.Max.X
+		}
+		if i&2 != 0 {
+			c.Y = b.Max.Y
+		}
+		if i&4 != 0 {
+			c.Z = b.Max.Z
+		}
+		corners = append(corners, m.MultV3(c))
+	}
+	want := PointsAABB3(corners)
+	if got := b.Transform(m); !v3eq(got.Min, want.Min) || !v3eq(got.Max, want.Max) {
+		t.Error("AABB3 Transform()", got, want)
+	}
+}
+
+func TestAABB2(t *testing.T) {
+	b := PointsAABB2([]V2{{1, 2}, {-1, 0}, {0, 4}})
+	if b.Min != (V2{-1, 0}) || b.Max != (V2{1, 4}) {
+		t.Error("PointsAABB2()", b)
+	}
+	if fne(b.Area(), 8) || fne(b.Perimeter(), 12) {
+		t.Error("AABB2 Area() Perimeter()")
+	}
+	if !b.Overlaps(AABB2{V2{1, 4}, V2{2, 5}}) || b.Overlaps(AABB2{V2{2, 0}, V2{3, 1}}) {
+		t.Error("AABB2 Overlaps()")
+	}
+	if b.ClosestPoint(V2{-5, 2}) != (V2{-1, 2}) {
+		t.Error("AABB2 ClosestPoint()")
+	}
+}
+
+func TestInverse(t *testing.T) {
+	_precision = 0.000001
+
+	m33 := RotateAxisM33(V3{1, 2, 3}, 0.9).Mult(M33{2, 0.5, 0, 0, 3, 0, 1, 0, 4})
+	inv, ok := m33.InverseOK()
+	if !ok || !m33eq(m33.Mult(inv), IdentityM33()) || !m33eq(inv.Mult(m33), IdentityM33()) {
+		t.Error("M33 InverseOK()")
+	}
+	if !m33eq(m33.Inverse().Inverse(), m33) {
+		t.Error("M33 Inverse() round trip")
+	}
+	if fne(RotateAxisM33(V3{0, 1, 0}, 0.3).Transpose().Cond(), RotateAxisM33(V3{0, 1, 0}, 0.3).Cond()) {
+		t.Error("M33 Cond() transpose")
+	}
+
+	singular := M33{1, 2, 3, 2, 4, 6, 0, 1, 0}
+	if _, ok := singular.InverseOK(); ok || !math.IsInf(singular.Cond(), 1) {
+		t.Error("M33 InverseOK() singular")
+	}
+	if singular.Inverse() != IdentityM33() {
+		t.Error("M33 Inverse() singular")
+	}
+
+	// nearly singular: the last column is almost the sum of the others
+	near := M33{1, 0, 0, 0, 1, 0, 1, 1, 1e-15}
+	if _, ok := near.InverseOK(); ok {
+		t.Error("M33 InverseOK() near singular", near.Cond())
+	}
+	near[8] = 1e-3
+	if _, ok := near.InverseOK(); !ok {
+		t.Error("M33 InverseOK() well enough conditioned", near.Cond())
+	}
+
+	m := ComposeM44(V3{1, 2, 3}, AxisAngleQ(V3{0, 1, 0}, 0.4), V3{2, 3, 0.5}, V3{0.1, 0, 0.2}, V4{0, 0, 0, 1})
+	m44, ok := m.InverseOK()
+	if !ok || !m44eq(m.Mult(m44), IdentityM44()) {
+		t.Error("M44 InverseOK()")
+	}
+	if !m44eq(m.InverseAffine(), m44) {
+		t.Error("M44 InverseAffine()")
+	}
+
+	rigid := TranslateM44(V3{1, -2, 3}).Mult(RotateAxisM33(V3{1, 1, 1}, 2).M44())
+	if !m44eq(rigid.InverseRigid(), rigid.Inverse()) {
+		t.Error("M44 InverseRigid()")
+	}
+	if rigid.M33().Cond() > 3 {
+		t.Error("M33 Cond() rotation", rigid.M33().Cond())
+	}
+
+	if _, ok := ScaleM44(V3{1, 0, 1}).InverseOK(); ok {
+		t.Error("M44 InverseOK() singular")
+	}
+	if _, ok := ScaleM44(V3{1, 1e-14, 1}).InverseOK(); ok {
+		t.Error("M44 InverseOK() near singular")
+	}
+}
+
+func TestLookAt(t *testing.T) {
+	_precision = 0.00001
+
+	eye := V3{3, 4, 5}
+	target := V3{-1, 2, 0}
+	up := V3{0, 1, 0}
+
+	m := LookAtM44(eye, target, up)
+	if !v3eq(m.MultV3(eye), V3{}) {
+		t.Error("LookAtM44() eye")
+	}
+	if !v3eq(m.MultV3(target), V3{0, 0, -eye.Dist(target)}) {
+		t.Error("LookAtM44() target", m.MultV3(target))
+	}
+	if p := m.MultV3(eye.Add(up)); p.Y <= 0 || fne(p.X, 0) {
+		t.Error("LookAtM44() up", p)
+	}
+	if !m33eq(LookAtQ(eye, target, up).M33(), m.Inverse().M33()) {
+		t.Error("LookAtQ()")
+	}
+	if !m44eq(LookAtM44(V3{}, V3{0, -5, 0}, up).Mult(LookAtM44(V3{}, V3{0, -5, 0}, up).Inverse()), IdentityM44()) {
+		t.Error("LookAtM44() parallel to up")
+	}
+
+	cam := Camera{Position: eye}
+	cam.LookAt(target, up)
+	if !m44eq(cam.ModelView, m) {
+		t.Error("Camera LookAt()")
+	}
+}
+
+func TestM33Euler(t *testing.T) {
+	_precision = 0.00001
+
+	for _, e := range []Euler{{0.1, 0.2, 0.3}, {-2, 1, 3}, {0.5, τ / 4, 0}, {0.5, -τ / 4, 0}} {
+		if !m33eq(e.M33().Euler().M33(), e.M33()) {
+			t.Error("Euler M33() Euler()", e, e.M33().Euler())
+		}
+	}
+}
+
+func TestControllers(t *testing.T) {
+	_precision = 0.00001
+
+	var cam Camera
+
+	orbit := OrbitController{Pivot: V3{1, 2, 3}, Distance: 10, MinDistance: 2}
+	orbit.Rotate(0.7, 3) // pitch gets clamped
+	orbit.Zoom(0.1)
+	orbit.Update(&cam)
+	if fne(orbit.Distance, 2) || float64(orbit.Pitch) >= τ/4 {
+		t.Error("OrbitController clamping", orbit)
+	}
+	if fne(cam.Position.Dist(orbit.Pivot), 2) {
+		t.Error("OrbitController distance")
+	}
+	if p := cam.ModelView.MultV3(orbit.Pivot); !v3eq(p, V3{0, 0, -2}) {
+		t.Error("OrbitController not looking at pivot", p)
+	}
+
+	fps := FirstPersonController{Position: V3{0, 1, 0}}
+	fps.Look(τ/4, -0.5)
+	fps.Move(2, 0, 0)
+	fps.Update(&cam)
+	if !v3eq(cam.Position, V3{-2, 1, 0}) {
+		t.Error("FirstPersonController Move()", cam.Position)
+	}
+	if p := cam.ModelView.MultV3(V3{-10, 1, 0}); p.Z >= 0 || fne(p.X, 0) {
+		t.Error("FirstPersonController facing", p)
+	}
+
+	var fly FreeFlyController
+	fly.Rotate(τ/4, 0, 0)
+	fly.Rotate(0, τ/4, 0)
+	fly.Move(1, 0, 0)
+	fly.Update(&cam)
+	if !v3eq(cam.Position, V3{0, 1, 0}) {
+		t.Error("FreeFlyController Move()", cam.Position)
+	}
+	if p := cam.ModelView.MultV3(V3{0, 5, 0}); !v3eq(p, V3{0, 0, -4}) {
+		t.Error("FreeFlyController facing", p)
+	}
+}
+
+func TestOrtho(t *testing.T) {
+	_precision = 0.00001
+
+	m := Ortho(-2, 2, -1, 1, 1, 11)
+	if !v3eq(m.MultV3(V3{2, 1, -1}), V3{1, 1, -1}) || !v3eq(m.MultV3(V3{-2, -1, -11}), V3{-1, -1, 1}) {
+		t.Error("Ortho()")
+	}
+
+	// off center, so the translation matters
+	m = Ortho(0, 4, 2, 4, 1, 11)
+	if !v3eq(m.MultV3(V3{4, 4, -1}), V3{1, 1, -1}) || !v3eq(m.MultV3(V3{0, 2, -11}), V3{-1, -1, 1}) {
+		t.Error("Ortho() off center")
+	}
+	if m.Row(3) != (V4{0, 0, 0, 1}) || !v3eq(m.TranslatePart(), V3{-1, -3, -1.2}) {
+		t.Error("Ortho() translation isn't in the last column", m.Row(3), m.TranslatePart())
+	}
+}
+
+func TestCameraModes(t *testing.T) {
+	_precision = 0.0001
+
+	depth := func(cam *Camera, d float64) float64 {
+		return cam.Projection.MultV4(V4{0, 0, -d, 1}).HomogeneousToCartesian().Z
+	}
+
+	for _, c := range []struct {
+		name     string
+		cam      Camera
+		near     float64
+		far      float64
+		parallel bool
+	}{
+		{"perspective", Camera{}, -1, 1, false},
+		{"infinite", Camera{InfiniteFar: true}, -1, 1 - 2.0/100, false},
+		{"reversed", Camera{ReverseZ: true}, 1, 0, false},
+		{"reversed infinite", Camera{ReverseZ: true, InfiniteFar: true}, 1, 1.0 / 100, false},
+		{"ortho", Camera{Ortho: 1, OrthoHeight: 10}, -1, 1, true},
+		{"ortho reversed", Camera{Ortho: 1, OrthoHeight: 10, ReverseZ: true}, 1, 0, true},
+		{"half ortho", Camera{Ortho: 0.5, OrthoHeight: 10}, -1, 1, false},
+	} {
+		cam := c.cam
+		cam.Width, cam.Height, cam.YFov, cam.Near, cam.Far = 800, 600, 60, 1, 100
+		cam.Position = V3{1, 2, 3}
+		cam.SetupViewProjection()
+		cam.SetupModelView()
+
+		if fne(depth(&cam, 1), c.near) || fne(depth(&cam, 100), c.far) {
+			t.Errorf("%s: depth %v %v", c.name, depth(&cam, 1), depth(&cam, 100))
+		}
+
+		// the middle of the screen is straight ahead
+		mid := cam.Unproject(400, 300)
+		if !v3eq(mid[0], V3{1, 2, 2}) || !v3eq(mid[1], V3{1, 2, -97}) {
+			t.Errorf("%s: Unproject() middle %v", c.name, mid)
+		}
+
+		// the unprojected points must project back to the same pixel
+		corner := cam.Unproject(0, 0)
+		for _, p := range corner {
+			ndc := cam.ModelViewProjection.MultV4(p.CartesianToHomogeneous()).HomogeneousToCartesian()
+			if fne(ndc.X, -1) || fne(ndc.Y, 1) {
+				t.Errorf("%s: Unproject() corner %v", c.name, ndc)
+			}
+		}
+		d := corner[1].Sub(corner[0])
+		if parallel := feq(d.X, 0) && feq(d.Y, 0); parallel != c.parallel {
+			t.Errorf("%s: Unproject() parallel rays %v", c.name, d)
+		}
+
+		f := cam.FrustumPlanes()
+		if f.ClassifyPoint(V3{1, 2, 1}) != Inside || f.ClassifyPoint(V3{1, 2, 2.5}) != Outside {
+			t.Errorf("%s: FrustumPlanes() near", c.name)
+		}
+	}
+
+	cam := Camera{Width: 800, Height: 600, YFov: 60, Near: 1, Far: 100, Ortho: 1}
+	cam.SetupModelView()
+	cam.FitOrtho(AABB3{V3{10, 0, -20}, V3{14, 6, -10}})
+	if !v3eq(cam.Position, V3{12, 3, 0}) || fne(cam.OrthoHeight, 6) {
+		t.Error("Camera FitOrtho()", cam.Position, cam.OrthoHeight)
+	}
+	cam.FitOrtho(AABB3{V3{10, 0, -20}, V3{22, 6, -10}})
+	if fne(cam.OrthoHeight, 9) {
+		t.Error("Camera FitOrtho() wide", cam.OrthoHeight)
+	}
+
+	cam.MatchOrthoHeight(10)
+	if fne(cam.OrthoHeight, 20*math.Tan(τ/12)) {
+		t.Error("Camera MatchOrthoHeight()", cam.OrthoHeight)
+	}
+}
+
+func TestCameraProject(t *testing.T) {
+	_precision = 0.0001
+
+	for _, reverse := range []bool{false, true} {
+		cam := Camera{Width: 800, Height: 600, YFov: 60, Near: 1, Far: 100, ReverseZ: reverse}
+		cam.Position = V3{1, 2, 3}
+		cam.RotAxis = Euler{0.1, 0.2, 0.3}
+		cam.SetupViewProjection()
+		cam.SetupModelView()
+
+		for _, px := range [][2]float64{{400, 300}, {1, 1}, {10, 590}, {799, 1}} {
+			u := cam.Unproject(px[0], px[1])
+			for _, p := range u {
+				s := cam.Project(p)
+				if fne(s.X, px[0]) || fne(s.Y, px[1]) {
+					t.Error("Camera Project() Unproject() round trip", px, s)
+				}
+			}
+			if s := cam.Project(Line{u[0], u[1]}.Lerp(0.5)); s.Behind || s.Outside {
+				t.Error("Camera Project() inside", s)
+			}
+		}
+
+		ahead := cam.ModelViewInverse.MultV3(V3{0, 0, -10})
+		behind := cam.ModelViewInverse.MultV3(V3{0, 0, 10})
+		tooclose := cam.ModelViewInverse.MultV3(V3{0, 0, -0.5})
+		toofar := cam.ModelViewInverse.MultV3(V3{0, 0, -200})
+		offside := cam.ModelViewInverse.MultV3(V3{100, 0, -10})
+
+		got := cam.ProjectAll(nil, []V3{ahead, behind, tooclose, toofar, offside})
+		if len(got) != 5 {
+			t.Fatal("Camera ProjectAll() length")
+		}
+		if got[0].Behind || got[0].Outside || fne(got[0].X, 400) || fne(got[0].Y, 300) {
+			t.Error("Camera Project() ahead", got[0])
+		}
+		if !got[1].Behind || !got[1].Outside {
+			t.Error("Camera Project() behind", got[1])
+		}
+		for _, s := range got[2:] {
+			if s.Behind || !s.Outside {
+				t.Error("Camera Project() outside", s)
+			}
+		}
+		if reverse && (got[0].Depth < 0 || got[0].Depth > 1) {
+			t.Error("Camera Project() reversed depth", got[0].Depth)
+		}
+	}
+}
+
+func TestEulerAngles(t *testing.T) {
+	_precision = 0.000001
+
+	e := Euler{0.3, -0.7, 1.1}
+	if !m33eq(e.EulerAngles().M33(), e.M33()) || !roteq(e.EulerAngles().Q(), e.Q()) {
+		t.Error("Euler EulerAngles()")
+	}
+	if !m33eq(e.Q().M33(), e.M33()) {
+		t.Error("Euler Q() M33()")
+	}
+	if !m33eq(e.Q().Euler().M33(), e.M33()) || !m33eq(e.M33().Euler().M33(), e.M33()) {
+		t.Error("Euler round trip")
+	}
+	zyx := EulerAngles{ZYX, false, e.Z, e.Y, e.X}
+	if !m33eq(zyx.M33(), e.M33()) {
+		t.Error("EulerAngles intrinsic ZYX vs extrinsic XYZ")
+	}
+
+	// B values covering the whole range, including right at and next to
+	// the gimbal lock for both kinds of order.
+	bs := []float64{-τ / 4, -τ/4 + 1e-9, -1.2, -0.1, 0, 1e-9, 0.4, 1.5, τ/4 - 1e-9, τ / 4, τ/2 - 1e-9, τ / 2}
+
+	for order := XYZ; order <= ZYZ; order++ {
+		for _, extrinsic := range []bool{false, true} {
+			for _, b := range bs {
+				in := EulerAngles{order, extrinsic, 0.4, Radian(b), -2.9}
+				m := in.M33()
+				if !m33eq(in.Q().M33(), m) {
+					t.Errorf("%v %v EulerAngles Q() vs M33()", order, extrinsic)
+				}
+
+				out := m.EulerAngles(order, extrinsic)
+				if out.Order != order || out.Extrinsic != extrinsic {
+					t.Errorf("%v %v M33 EulerAngles() order", order, extrinsic)
+				}
+				if !m33eq(out.M33(), m) {
+					t.Errorf("%v %v b=%v M33 EulerAngles() round trip: %v", order, extrinsic, b, out)
+				}
+				if out := in.Q().EulerAngles(order, extrinsic); !m33eq(out.M33(), m) {
+					t.Errorf("%v %v b=%v Q EulerAngles() round trip: %v", order, extrinsic, b, out)
+				}
+
+				// away from the edges the angles themselves come back
+				proper := order >= XYX
+				if (!proper && math.Abs(b) < 1.5) || (proper && b > 0.1 && b < 3) {
+					if fne(float64(out.A), float64(in.A)) || fne(float64(out.B), float64(in.B)) || fne(float64(out.C), float64(in.C)) {
+						t.Errorf("%v %v angles %v -> %v", order, extrinsic, in, out)
+					}
+				}
+			}
+		}
+	}
+
+	// an invalid order is no rotation, rather than an index out of range
+	for _, order := range []RotationOrder{-1, ZYZ + 1} {
+		bad := EulerAngles{order, true, 0.4, 0.5, 0.6}
+		if order.String() != "invalid" || order.Reverse() != order {
+			t.Error("invalid RotationOrder", int(order))
+		}
+		if !m33eq(bad.M33(), IdentityM33()) || !qeq(bad.Q(), IdentityQ()) {
+			t.Error("invalid RotationOrder EulerAngles M33() Q()", int(order))
+		}
+		if got := e.M33().EulerAngles(order, false); got != (EulerAngles{Order: order}) {
+			t.Error("invalid RotationOrder M33 EulerAngles()", got)
+		}
+	}
+}
+
+func TestColorSpaces(t *testing.T) {
+	_precision = 0.0001
+
+	rgbeq := func(a, b RGB) bool {
+		return feq(a.R, b.R) && feq(a.G, b.G) && feq(a.B, b.B)
+	}
+
+	if fne(RGB{0.5, 0, 1}.Linear().R, 0.21404) || !rgbeq(RGB{0.5, 0.2, 0.9}.Linear().SRGB(), RGB{0.5, 0.2, 0.9}) {
+		t.Error("RGB Linear() SRGB()")
+	}
+
+	orange := RGB{1, 0.5, 0}
+	if hsv := orange.HSV(); fne(float64(hsv.H), 30) || fne(hsv.S, 1) || fne(hsv.V, 1) {
+		t.Error("RGB HSV()", hsv)
+	}
+	if hsl := orange.HSL(); fne(float64(hsl.H), 30) || fne(hsl.S, 1) || fne(hsl.L, 0.5) {
+		t.Error("RGB HSL()", hsl)
+	}
+
+	_precision = 0.01
+	if lab := (RGB{1, 0, 0}).Lab(); fne(lab.L, 53.24) || fne(lab.A, 80.09) || fne(lab.B, 67.20) {
+		t.Error("RGB Lab() red", lab)
+	}
+	if lab := (RGB{1, 1, 1}).Lab(); fne(lab.L, 100) || fne(lab.A, 0) || fne(lab.B, 0) {
+		t.Error("RGB Lab() white", lab)
+	}
+	_precision = 0.0001
+	if ok := (RGB{1, 0, 0}).OKLab(); fne(ok.L, 0.62796) || fne(ok.A, 0.22486) || fne(ok.B, 0.12585) {
+		t.Error("RGB OKLab() red", ok)
+	}
+
+	for _, c := range []RGB{{0, 0, 0}, {1, 1, 1}, {0.2, 0.4, 0.6}, {0.9, 0.1, 0.3}, {0, 1, 0.5}, {0.5, 0.5, 0.5}} {
+		if !rgbeq(c.HSV().RGB(), c) || !rgbeq(c.HSL().RGB(), c) || !rgbeq(c.CIEXYZ().RGB(), c) ||
+			!rgbeq(c.Lab().RGB(), c) || !rgbeq(c.OKLab().RGB(), c) {
+			t.Error("color space round trip", c)
+		}
+	}
+
+	// the conversions back are exact inverses, not just to the 7 digits
+	// the published matrices have
+	_precision = 1e-12
+	lr := rand.New(rand.NewSource(1))
+	for i := 0; i < 10000; i++ {
+		c := RGB{lr.Float64(), lr.Float64(), lr.Float64()}
+		if !rgbeq(c.Lab().RGB(), c) || !rgbeq(c.OKLab().RGB(), c) {
+			t.Fatal("color space precise round trip", c, c.Lab().RGB(), c.OKLab().RGB())
+		}
+	}
+}
+
+func TestColorFormats(t *testing.T) {
+	_precision = 0.0001
+
+	c, err := HexRGBA("#f80")
+	if err != nil || c != (RGBA{1, 0x88 / 255.0, 0, 1}) {
+		t.Error("HexRGBA() short", c, err)
+	}
+	c, err = HexRGBA("12345678")
+	if err != nil || c.Uint32() != 0x12345678 || c.Hex() != "#12345678" {
+		t.Error("HexRGBA() long", c, err)
+	}
+	if _, err = HexRGBA("#12345"); err == nil {
+		t.Error("HexRGBA() bad length")
+	}
+	if _, err = HexRGB("#zzzzzz"); err == nil {
+		t.Error("HexRGB() bad digits")
+	}
+	if rgb, _ := HexRGB("#ff8000"); rgb.Hex() != "#ff8000" || rgb.Uint32() != 0xff8000 || Uint32RGB(0xff8000) != rgb {
+		t.Error("RGB Hex() Uint32()")
+	}
+
+	var cc color.Color = RGBA{1, 0.5, 0, 1}
+	if n := color.NRGBAModel.Convert(cc).(color.NRGBA); n != (color.NRGBA{255, 128, 0, 255}) {
+		t.Error("RGBA image/color", n)
+	}
+
+	// 8 bits per channel isn't going to survive premultiplying exactly
+	_precision = 1.0 / 255
+	back := ColorRGBA(color.NRGBA{255, 128, 0, 128})
+	if fne(back.R, 1) || fne(back.G, 0.5) || fne(back.B, 0) || fne(back.A, 0.5) {
+		t.Error("ColorRGBA()", back)
+	}
+	back = ColorRGBA(RGBA{0.2, 0.4, 0.6, 0.3})
+	if fne(back.R, 0.2) || fne(back.G, 0.4) || fne(back.B, 0.6) || fne(back.A, 0.3) {
+		t.Error("RGBA RGBA() ColorRGBA()", back)
+	}
+	if ColorRGBA(RGB{0.2, 0.4, 0.6}).A != 1 {
+		t.Error("RGB image/color alpha")
+	}
+}
+
+func rgbaeq(a, b RGBA) bool {
+	return feq(a.R, b.R) && feq(a.G, b.G) && feq(a.B, b.B) && feq(a.A, b.A)
+}
+
+func TestBlend(t *testing.T) {
+	_precision = 0.00001
+
+	c := RGBA{0.8, 0.4, 0.2, 0.5}
+	if !rgbaeq(c.Premultiply(), RGBA{0.4, 0.2, 0.1, 0.5}) || !rgbaeq(c.Premultiply().Unpremultiply(), c) {
+		t.Error("RGBA Premultiply() Unpremultiply()")
+	}
+	if (RGBA{1, 1, 1, 0}).Unpremultiply() != (RGBA{}) {
+		t.Error("RGBA Unpremultiply() transparent")
+	}
+
+	red := RGBA{1, 0, 0, 0.5}
+	blue := RGBA{0, 0, 1, 1}
+	for _, c := range []struct {
+		op   CompositeOp
+		want RGBA
+	}{
+		{CompositeClear, RGBA{}},
+		{CompositeSrc, red},
+		{CompositeDst, blue},
+		{CompositeSrcOver, RGBA{0.5, 0, 0.5, 1}},
+		{CompositeDstOver, blue},
+		{CompositeSrcIn, red},
+		{CompositeDstIn, RGBA{0, 0, 1, 0.5}},
+		{CompositeSrcOut, RGBA{}},
+		{CompositeDstOut, RGBA{0, 0, 1, 0.5}},
+		{CompositeSrcAtop, RGBA{0.5, 0, 0.5, 1}},
+		{CompositeDstAtop, RGBA{0, 0, 1, 0.5}},
+		{CompositeXor, RGBA{0, 0, 1, 0.5}},
+	} {
+		if got := red.Composite(blue, c.op); !rgbaeq(got, c.want) {
+			t.Errorf("RGBA Composite(%d) = %v, want %v", c.op, got, c.want)
+		}
+	}
+	if !rgbaeq(red.Over(RGBA{}), red) {
+		t.Error("RGBA Over() transparent")
+	}
+
+	s := RGBA{0.5, 0.25, 1, 1}
+	d := RGBA{0.5, 0.5, 0.25, 1}
+	for _, c := range []struct {
+		mode BlendMode
+		want RGBA
+	}{
+		{BlendNormal, s},
+		{BlendMultiply, RGBA{0.25, 0.125, 0.25, 1}},
+		{BlendScreen, RGBA{0.75, 0.625, 1, 1}},
+		{BlendOverlay, RGBA{0.5, 0.25, 0.5, 1}},
+		{BlendAdditive, RGBA{1, 0.75, 1, 1}},
+	} {
+		if got := s.Blend(d, c.mode); !rgbaeq(got, c.want) {
+			t.Errorf("RGBA Blend(%d) = %v, want %v", c.mode, got, c.want)
+		}
+	}
+	if !rgbaeq(s.Blend(RGBA{}, BlendMultiply), s) {
+		t.Error("RGBA Blend() onto transparent")
+	}
+}
+
+func TestColorLerp(t *testing.T) {
+	_precision = 0.0001
+
+	a := RGBA{1, 0, 0, 1}
+	b := RGBA{0, 0, 1, 0}
+	for space := SpaceSRGB; space <= SpaceOKLab; space++ {
+		if !rgbaeq(a.Lerp(b, 0, space), a) || !rgbaeq(a.Lerp(b, 1, space), b) {
+			t.Errorf("RGBA Lerp(%d) endpoints", space)
+		}
+		if fne(a.Lerp(b, 0.25, space).A, 0.75) {
+			t.Errorf("RGBA Lerp(%d) alpha", space)
+		}
+	}
+	// a transparent end fades out without changing color
+	for space := SpaceSRGB; space <= SpaceOKLab; space++ {
+		if got := a.Lerp(b, 0.5, space); !rgbaeq(got, RGBA{1, 0, 0, 0.5}) {
+			t.Errorf("RGBA Lerp(%d) to transparent = %v", space, got)
+		}
+		if got := (RGBA{}).Lerp(RGBA{1, 1, 1, 1}, 0.5, space); !rgbaeq(got, RGBA{1, 1, 1, 0.5}) {
+			t.Errorf("RGBA Lerp(%d) from transparent black = %v", space, got)
+		}
+	}
+
+	b.A = 1
+	if !rgbaeq(a.Lerp(b, 0.5, SpaceSRGB), RGBA{0.5, 0, 0.5, 1}) {
+		t.Error("RGBA Lerp() sRGB")
+	}
+	if got := a.Lerp(b, 0.5, SpaceLinear); fne(got.R, RGB{0.5, 0, 0}.SRGB().R) {
+		t.Error("RGBA Lerp() linear", got)
+	}
+	// red to blue the short way is through magenta, not green
+	if got := a.Lerp(b, 0.5, SpaceHSV); !rgbaeq(got, RGBA{1, 0, 1, 1}) {
+		t.Error("RGBA Lerp() HSV", got)
+	}
+	// premultiplied, so the more opaque end counts for more
+	if got := a.Lerp(RGBA{0, 0, 1, 0.5}, 0.5, SpaceSRGB); !rgbaeq(got, RGBA{2.0 / 3, 0, 1.0 / 3, 0.75}) {
+		t.Error("RGBA Lerp() half transparent", got)
+	}
+	// grey has no hue, so only saturation changes
+	if got := (RGBA{0.5, 0.5, 0.5, 1}).Lerp(RGBA{0, 1, 0, 1}, 0.5, SpaceHSL).RGB().HSL(); fne(float64(got.H), 120) {
+		t.Error("RGBA Lerp() HSL grey", got)
+	}
+}
+
+func TestGradient(t *testing.T) {
+	_precision = 0.00001
+
+	var g Gradient
+	if g.At(0.5) != (RGBA{}) {
+		t.Error("Gradient At() empty")
+	}
+
+	g.Add(1, RGBA{0, 0, 1, 1})
+	g.Add(0, RGBA{1, 0, 0, 1})
+	g.Add(0.5, RGBA{0, 1, 0, 1})
+	g.Add(0.5, RGBA{1, 1, 1, 1})
+
+	for i := 1; i < len(g.Stops); i++ {
+		if g.Stops[i].Pos < g.Stops[i-1].Pos {
+			t.Fatal("Gradient Add() order")
+		}
+	}
+
+	for _, c := range []struct {
+		t    float64
+		want RGBA
+	}{
+		{-1, RGBA{1, 0, 0, 1}},
+		{0, RGBA{1, 0, 0, 1}},
+		{0.25, RGBA{0.5, 0.5, 0, 1}},
+		{0.4999999, RGBA{0, 1, 0, 1}},
+		{0.5, RGBA{1, 1, 1, 1}},
+		{0.75, RGBA{0.5, 0.5, 1, 1}},
+		{1, RGBA{0, 0, 1, 1}},
+		{2, RGBA{0, 0, 1, 1}},
+	} {
+		if got := g.At(c.t); !rgbaeq(got, c.want) {
+			t.Errorf("Gradient At(%v) = %v, want %v", c.t, got, c.want)
+		}
+	}
+
+	// fading to transparent keeps the color, rather than going through the
+	// transparent stop's
+	g = Gradient{}
+	g.Add(0, RGBA{1, 0, 0, 1})
+	g.Add(1, RGBA{0, 0, 1, 0})
+	if got := g.At(0.5); !rgbaeq(got, RGBA{1, 0, 0, 0.5}) {
+		t.Error("Gradient At() transparent stop", got)
+	}
+}
+
+func TestEncoding(t *testing.T) {
+	third := 1.0 / 3
+	values := []interface{}{
+		&V2{0.1, -third},
+		&V3{1e-300, math.MaxFloat64, -0.0},
+		&V4{1, 2, 3, math.SmallestNonzeroFloat64},
+		&Q{0.1, 0.2, 0.3, third},
+		&Euler{0.1, Radian(third), -2},
+		&M23{1, 2, 3, 4, 5, third},
+		&M33{1, 2, 3, 4, 5, 6, 7, 8, third},
+		&M34{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, third},
+		&M44{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, third},
+		&RGB{0.1, 0.2, third},
+		&RGBA{0.1, 0.2, 0.3, third},
+	}
+
+	// a fresh zero value of the same type as v
+	zero := func(v interface{}) interface{} {
+		switch v.(type) {
+		case *V2:
+			return new(V2)
+		case *V3:
+			return new(V3)
+		case *V4:
+			return new(V4)
+		case *Q:
+			return new(Q)
+		case *Euler:
+			return new(Euler)
+		case *M23:
+			return new(M23)
+		case *M33:
+			return new(M33)
+		case *M34:
+			return new(M34)
+		case *M44:
+			return new(M44)
+		case *RGB:
+			return new(RGB)
+		case *RGBA:
+			return new(RGBA)
+		}
+		panic("unknown type")
+	}
+
+	for _, v := range values {
+		b, err := v.(encoding.BinaryMarshaler).MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+		out := zero(v)
+		if err := out.(encoding.BinaryUnmarshaler).UnmarshalBinary(b); err != nil {
+			t.Errorf("%T UnmarshalBinary(): %v", v, err)
+		}
+		if b2, _ := out.(encoding.BinaryMarshaler).MarshalBinary(); !bytes.Equal(b, b2) {
+			t.Errorf("%T binary round trip", v)
+		}
+		if err := zero(v).(encoding.BinaryUnmarshaler).UnmarshalBinary(b[1:]); err == nil {
+			t.Errorf("%T UnmarshalBinary() short data", v)
+		}
+
+		text, err := v.(encoding.TextMarshaler).MarshalText()
+		if err != nil {
+			t.Fatal(err)
+		}
+		out = zero(v)
+		if err := out.(encoding.TextUnmarshaler).UnmarshalText(text); err != nil {
+			t.Errorf("%T UnmarshalText(%s): %v", v, text, err)
+		}
+		if b2, _ := out.(encoding.BinaryMarshaler).MarshalBinary(); !bytes.Equal(b, b2) {
+			t.Errorf("%T text round trip: %s", v, text)
+		}
+		if err := zero(v).(encoding.TextUnmarshaler).UnmarshalText(text[:bytes.LastIndexByte(text, ' ')]); err == nil {
+			t.Errorf("%T UnmarshalText() missing number", v)
+		}
+
+		js, err := json.Marshal(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		out = zero(v)
+		if err := json.Unmarshal(js, out); err != nil {
+			t.Errorf("%T json.Unmarshal(%s): %v", v, js, err)
+		}
+		if b2, _ := out.(encoding.BinaryMarshaler).MarshalBinary(); !bytes.Equal(b, b2) {
+			t.Errorf("%T JSON round trip: %s", v, js)
+		}
+	}
+
+	if js, _ := json.Marshal(V3{1, 2.5, -3}); string(js) != "[1,2.5,-3]" {
+		t.Error("V3 MarshalJSON()", string(js))
+	}
+	if text, _ := (V3{1, 2.5, -3}).MarshalText(); string(text) != "1 2.5 -3" {
+		t.Error("V3 MarshalText()", string(text))
+	}
+	if b, _ := (V2{1, -2}).MarshalBinary(); !bytes.Equal(b, []byte{0, 0, 0, 0, 0, 0, 0xf0, 0x3f, 0, 0, 0, 0, 0, 0, 0, 0xc0}) {
+		t.Errorf("V2 MarshalBinary() layout % x", b)
+	}
+	var v V3
+	if err := json.Unmarshal([]byte("[1,2]"), &v); err == nil {
+		t.Error("V3 UnmarshalJSON() short array")
+	}
+
+	// null is a no-op, in struct fields, slices, and called directly
+	v = V3{1, 2, 3}
+	if err := v.UnmarshalJSON([]byte("null")); err != nil || v != (V3{1, 2, 3}) {
+		t.Error("V3 UnmarshalJSON(null)", v, err)
+	}
+	var scene struct {
+		P V3
+		M M44
+		L []V3
+	}
+	scene.P = V3{1, 2, 3}
+	if err := json.Unmarshal([]byte(`{"P":null,"M":null,"L":[[1,2,3],null]}`), &scene); err != nil {
+		t.Error("json.Unmarshal() with nulls", err)
+	}
+	if scene.P != (V3{1, 2, 3}) || scene.M != (M44{}) || len(scene.L) != 2 || scene.L[0] != (V3{1, 2, 3}) || scene.L[1] != (V3{}) {
+		t.Error("json.Unmarshal() with nulls", scene)
+	}
+
+	// maps with vector keys go through MarshalText
+	js, err := json.Marshal(map[V2]int{{1, 2}: 3})
+	if err != nil || string(js) != `{"1 2":3}` {
+		t.Error("V2 map key", string(js), err)
+	}
+
+	vs := []V3{{1, 2, 3}, {4, 5, 6}, {third, 0, -1}}
+	b := AppendBinary([]byte("hdr"), vs)
+	if len(b) != 3+3*3*8 {
+		t.Fatal("AppendBinary() length", len(b))
+	}
+	if one, _ := vs[1].MarshalBinary(); !bytes.Equal(b[3+24:3+48], one) {
+		t.Error("AppendBinary() layout")
+	}
+	back, err := DecodeBinary[V3](b[3:])
+	if err != nil || len(back) != 3 || back[2] != vs[2] {
+		t.Error("DecodeBinary()", back, err)
+	}
+	if _, err := DecodeBinary[M44](b[3:]); err == nil {
+		t.Error("DecodeBinary() bad length")
+	}
+}
+
+func TestMatrixLayout(t *testing.T) {
+	m := RowsM44(
+		V4{1, 2, 3, 4},
+		V4{5, 6, 7, 8},
+		V4{9, 10, 11, 12},
+		V4{13, 14, 15, 16})
+
+	// storage is column major
+	if m != (M44{1, 5, 9, 13, 2, 6, 10, 14, 3, 7, 11, 15, 4, 8, 12, 16}) {
+		t.Error("RowsM44() layout", m)
+	}
+	if ColsM44(m.Col(0), m.Col(1), m.Col(2), m.Col(3)) != m {
+		t.Error("ColsM44() Col()")
+	}
+	if m.At(0, 3) != 4 || m.At(3, 0) != 13 || m.Row(1) != (V4{5, 6, 7, 8}) || m.Col(1) != (V4{2, 6, 10, 14}) {
+		t.Error("M44 At() Row() Col()")
+	}
+	m.Set(2, 1, 100)
+	if m[6] != 100 || m.At(2, 1) != 100 {
+		t.Error("M44 Set()")
+	}
+
+	// translation is in the last column, and points are column vectors
+	tr := TranslateM44(V3{7, 8, 9})
+	if tr.Col(3) != (V4{7, 8, 9, 1}) || tr.At(0, 3) != 7 {
+		t.Error("TranslateM44() layout")
+	}
+	if tr.MultV4(V4{1, 1, 1, 1}) != (V4{8, 9, 10, 1}) {
+		t.Error("M44 MultV4() column vector")
+	}
+
+	// Mult applies the right hand side first, MultX the left hand side
+	s := ScaleM44(V3{2, 2, 2})
+	if tr.Mult(s).MultV3(V3{1, 0, 0}) != (V3{9, 8, 9}) || tr.MultX(s).MultV3(V3{1, 0, 0}) != (V3{16, 16, 18}) {
+		t.Error("M44 Mult() MultX() order")
+	}
+
+	if c := tr.ColMajor32(); c[12] != 7 || c[3] != 0 {
+		t.Error("M44 ColMajor32()", c)
+	}
+	if r := tr.RowMajor32(); r[3] != 7 || r[7] != 8 || r[12] != 0 {
+		t.Error("M44 RowMajor32()", r)
+	}
+
+	m33 := RowsM33(V3{1, 2, 3}, V3{4, 5, 6}, V3{7, 8, 9})
+	if m33 != (M33{1, 4, 7, 2, 5, 8, 3, 6, 9}) || ColsM33(m33.Col(0), m33.Col(1), m33.Col(2)) != m33 {
+		t.Error("RowsM33() ColsM33()")
+	}
+	if m33.At(0, 2) != 3 || m33.Row(2) != (V3{7, 8, 9}) || m33.MultV3(V3{1, 0, 0}) != m33.Col(0) {
+		t.Error("M33 At() Row() Col()")
+	}
+	m33.Set(1, 0, 40)
+	if m33[1] != 40 {
+		t.Error("M33 Set()")
+	}
+	if r := m33.RowMajor32(); r != [9]float32{1, 2, 3, 40, 5, 6, 7, 8, 9} {
+		t.Error("M33 RowMajor32()", r)
+	}
+	if c := m33.ColMajor32(); c != [9]float32{1, 40, 7, 2, 5, 8, 3, 6, 9} {
+		t.Error("M33 ColMajor32()", c)
+	}
+	if p := m33.Std140(); p != [12]float32{1, 40, 7, 0, 2, 5, 8, 0, 3, 6, 9, 0} {
+		t.Error("M33 Std140()", p)
+	}
+}
+
+func TestM34(t *testing.T) {
+	q := AxisAngleQ(V3{1, 2, 3}.Normalize(), 0.7)
+	a := RotateQM34(q, V3{1, 2, 3})
+	b := RotateTranslateM34(AxisAngleQ(V3{0, 1, 0}, -1.2).M33(), V3{-4, 0, 5})
+	p := V3{0.5, -2, 7}
+
+	if !v3eq(a.MultV3(p), a.M44().MultV3(p)) {
+		t.Error("M34 MultV3() doesn't match M44", a.MultV3(p), a.M44().MultV3(p))
+	}
+	if !v3eq(a.MultDir(p), q.M33().MultV3(p)) {
+		t.Error("M34 MultDir()", a.MultDir(p))
+	}
+	if !m44eq(a.Mult(b).M44(), a.M44().Mult(b.M44())) {
+		t.Error("M34 Mult() doesn't match M44")
+	}
+	if a.M44().M34() != a || !m33eq(a.M33(), q.M33()) || a.TranslatePart() != (V3{1, 2, 3}) {
+		t.Error("M34 conversions")
+	}
+	if !m44eq(b.MultQ(q).M44(), b.Mult(RotateQM34(q, V3{})).M44()) || !v3eq(b.MultQ(q).MultV3(p), b.MultV3(q.M33().MultV3(p))) {
+		t.Error("M34 MultQ()")
+	}
+
+	if !v3eq(a.InverseRigid().MultV3(a.MultV3(p)), p) {
+		t.Error("M34 InverseRigid()")
+	}
+	if !m44eq(a.InverseRigid().M44(), a.Inverse().M44()) {
+		t.Error("M34 InverseRigid() doesn't match Inverse()")
+	}
+	s := RotateTranslateM34(ScaleM44(V3{2, 3, -4}).M33(), V3{1, 1, 1}).Mult(a)
+	if !v3eq(s.Inverse().MultV3(s.MultV3(p)), p) {
+		t.Error("M34 Inverse()")
+	}
+
+	// RotateTransposeM34 is the transpose of the rotation
+	if !m33eq(RotateTransposeM34(q.M33(), V3{}).M33(), q.M33().Transpose()) {
+		t.Error("RotateTransposeM34()")
+	}
+}
+
+func TestTransform(t *testing.T) {
+	a := Transform{V3{1, 2, 3}, AxisAngleQ(V3{0, 0, 1}, 0.5), V3{2, 2, 2}}
+	b := Transform{V3{-3, 0, 1}, AxisAngleQ(V3{1, 1, 0}.Normalize(), -1.1), V3{1, 2, 3}}
+	p := V3{0.25, -1, 4}
+
+	if !v3eq(a.TransformPoint(p), a.M44().MultV3(p)) || !v3eq(a.TransformPoint(p), a.M34().MultV3(p)) {
+		t.Error("Transform TransformPoint() doesn't match M44/M34")
+	}
+	if !v3eq(a.TransformDirection(p), a.M34().MultDir(p)) {
+		t.Error("Transform TransformDirection()")
+	}
+	if !m44eq(a.Compose(b).M44(), a.M44().Mult(b.M44())) {
+		t.Error("Transform Compose() doesn't match M44 Mult()")
+	}
+
+	// exact for uniform scale
+	if !v3eq(a.Inverse().TransformPoint(a.TransformPoint(p)), p) {
+		t.Error("Transform Inverse()")
+	}
+	if i := a.Compose(a.Inverse()); !v3eq(i.Position, V3{}) || !v3eq(i.Scale, V3{1, 1, 1}) || !roteq(i.Rotation, IdentityQ()) {
+		t.Error("Transform Compose(Inverse())", i)
+	}
+
+	d := b.M44().Transform()
+	if !v3eq(d.Position, b.Position) || !v3eq(d.Scale, b.Scale) || !roteq(d.Rotation, b.Rotation) {
+		t.Error("M44 Transform()", d)
+	}
+
+	if l := a.Lerp(b, 0); !m44eq(l.M44(), a.M44()) {
+		t.Error("Transform Lerp(0)")
+	}
+	if l := a.Lerp(b, 1); !m44eq(l.M44(), b.M44()) {
+		t.Error("Transform Lerp(1)")
+	}
+	if l := a.Lerp(b, 0.5); !v3eq(l.Position, V3{-1, 1, 2}) || !v3eq(l.Scale, V3{1.5, 2, 2.5}) || !roteq(l.Rotation, a.Rotation.Slerp(b.Rotation, 0.5)) {
+		t.Error("Transform Lerp(0.5)", l)
+	}
+	if IdentityTransform().M44() != IdentityM44() {
+		t.Error("IdentityTransform()")
+	}
+}
+
+func TestNode(t *testing.T) {
+	root := NewNode()
+	a := NewNode()
+	b := NewNode()
+	c := NewNode()
+	root.AddChild(a)
+	a.AddChild(b)
+	root.AddChild(c)
+
+	a.SetLocal(Transform{V3{1, 0, 0}, AxisAngleQ(V3{0, 0, 1}, math.Pi/2), V3{2, 2, 2}})
+	b.SetLocal(Transform{V3{0, 1, 0}, IdentityQ(), V3{1, 1, 1}})
+
+	if !v3eq(b.WorldPosition(), V3{-1, 0, 0}) {
+		t.Error("Node WorldPosition()", b.WorldPosition())
+	}
+	if !m44eq(b.WorldInverse().Mult(b.World()), IdentityM44()) {
+		t.Error("Node WorldInverse()")
+	}
+
+	// changing a parent moves the cached children
+	root.SetLocal(Transform{V3{0, 0, 5}, IdentityQ(), V3{1, 1, 1}})
+	if !v3eq(b.WorldPosition(), V3{-1, 0, 5}) {
+		t.Error("Node World() not updated", b.WorldPosition())
+	}
+	if !v3eq(b.WorldInverse().MultV3(V3{-1, 0, 5}), V3{}) {
+		t.Error("Node WorldInverse() not updated")
+	}
+
+	// Reparent keeps the world position, SetParent keeps the local one
+	w := b.World()
+	b.Reparent(c)
+	if b.Parent() != c || len(a.Children()) != 0 || !m44eq(b.World(), w) {
+		t.Error("Node Reparent()", b.World())
+	}
+	b.SetParent(nil)
+	if b.Parent() != nil || len(c.Children()) != 0 || !m44eq(b.World(), b.Local().M44()) {
+		t.Error("Node SetParent(nil)")
+	}
+	b.SetParent(a)
+
+	var order []*Node
+	root.Walk(func(n *Node) bool {
+		order = append(order, n)
+		return n != c
+	})
+	if len(order) != 4 || order[0] != root || order[1] != a || order[2] != b || order[3] != c {
+		t.Error("Node Walk() order")
+	}
+	order = order[:0]
+	root.WalkPost(func(n *Node) { order = append(order, n) })
+	if len(order) != 4 || order[0] != b || order[1] != a || order[2] != c || order[3] != root {
+		t.Error("Node WalkPost() order")
+	}
+	if b.Root() != root || !b.IsAncestor(root) || root.IsAncestor(b) {
+		t.Error("Node Root() IsAncestor()")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Node SetParent() allowed a cycle")
+		}
+	}()
+	a.SetParent(b)
+}
+
+func TestPlane(t *testing.T) {
+	p := PointsPlane(V3{0, 0, 2}, V3{1, 0, 2}, V3{0, 1, 2})
+	if !v3eq(p.Normal, V3{0, 0, 1}) || !feq(p.D, -2) {
+		t.Error("PointsPlane()", p)
+	}
+	if q := PointNormalPlane(V3{5, 5, 2}, V3{0, 0, 3}); !v3eq(q.Normal, p.Normal) || !feq(q.D, p.D) {
+		t.Error("PointNormalPlane()", q)
+	}
+
+	v := V3{3, -1, 5}
+	if !feq(p.SignedDistance(v), 3) || !v3eq(p.ProjectPoint(v), V3{3, -1, 2}) || !v3eq(p.ReflectPoint(v), V3{3, -1, -1}) {
+		t.Error("Plane ProjectPoint() ReflectPoint()")
+	}
+	if p.Side(v, 0.01) != PlaneFront || p.Side(V3{0, 0, 1}, 0.01) != PlaneBack || p.Side(V3{9, 9, 2.001}, 0.01) != PlaneOn {
+		t.Error("Plane Side()")
+	}
+
+	if !v3eq(ReflectM44(p).MultV3(v), p.ReflectPoint(v)) {
+		t.Error("ReflectM44()", ReflectM44(p).MultV3(v))
+	}
+
+	hit, at, ok := Line{V3{1, 1, 0}, V3{1, 1, 4}}.IntersectPlane(p)
+	if !ok || !v3eq(hit, V3{1, 1, 2}) || !feq(at, 0.5) {
+		t.Error("Line IntersectPlane()", hit, at, ok)
+	}
+	if _, _, ok := (Line{V3{1, 1, 3}, V3{1, 1, 4}}).IntersectPlane(p); ok {
+		t.Error("Line IntersectPlane() hit past the end")
+	}
+
+	x := PointNormalPlane(V3{1, 0, 0}, V3{1, 0, 0})
+	y := PointNormalPlane(V3{0, -3, 0}, V3{0, 1, 0})
+	r, ok := p.IntersectPlane(x)
+	if !ok || !v3eq(r.Origin, V3{1, 0, 2}) || !v3eq(r.Direction.Cross(V3{0, 1, 0}), V3{}) {
+		t.Error("Plane IntersectPlane()", r, ok)
+	}
+	if _, ok := p.IntersectPlane(PointNormalPlane(V3{}, V3{0, 0, -1})); ok {
+		t.Error("Plane IntersectPlane() parallel")
+	}
+
+	// Parallel planes built different ways have normals that differ by
+	// rounding, which has to count as parallel at either precision.
+	for _, n := range []V3{{0.3, -0.7, 0.2}, {2, -3, 5}} {
+		n = n.Normalize()
+		u := n.Cross(V3{0, 0, 1})
+		w := n.Cross(u)
+		o := V3{1, 0, 0}
+
+		a := PointsPlane(o, o.Add(u), o.Add(w))
+		if _, ok := a.IntersectPlane(PointNormalPlane(V3{0, 1, 0}, a.Normal)); ok {
+			t.Error("Plane IntersectPlane() nearly parallel", n)
+		}
+		if _, ok := IntersectPlanes(a, PointNormalPlane(V3{0, 1, 0}, a.Normal), x); ok {
+			t.Error("IntersectPlanes() nearly parallel", n)
+		}
+
+		plane32 := func(p Plane) vector32.Plane {
+			return vector32.Plane{Normal: p.Normal.Float32(), D: float32(p.D)}
+		}
+		a32 := vector32.PointsPlane(o.Float32(), o.Add(u).Float32(), o.Add(w).Float32())
+		b32 := vector32.PointNormalPlane(vector32.V3{Y: 1}, a32.Normal)
+		if _, ok := a32.IntersectPlane(b32); ok {
+			t.Error("float32 Plane IntersectPlane() nearly parallel", n)
+		}
+		if _, ok := vector32.IntersectPlanes(a32, b32, plane32(x)); ok {
+			t.Error("float32 IntersectPlanes() nearly parallel", n)
+		}
+
+		// but a real angle between them still works
+		tilt := PointNormalPlane(V3{0, 1, 0}, a.Normal.Add(u.Scale(1e-3)))
+		if _, ok := a.IntersectPlane(tilt); !ok {
+			t.Error("Plane IntersectPlane() small angle", n)
+		}
+		if _, ok := a32.IntersectPlane(plane32(tilt)); !ok {
+			t.Error("float32 Plane IntersectPlane() small angle", n)
+		}
+	}
+	if c, ok := IntersectPlanes(p, x, y); !ok || !v3eq(c, V3{1, -3, 2}) {
+		t.Error("IntersectPlanes()", c, ok)
+	}
+
+	// the transformed plane goes through the transformed points
+	m := TranslateM44(V3{1, 2, 3}).Mult(RotateQM34(AxisAngleQ(V3{1, 0, 0}, 0.3), V3{}).M44()).Mult(ScaleM44(V3{1, 2, 0.5}))
+	a, b, c := V3{1, 2, 0}, V3{-3, 4, 1}, V3{0, 0, 7}
+	tp := PointsPlane(a, b, c).Transform(m)
+	want := PointsPlane(m.MultV3(a), m.MultV3(b), m.MultV3(c))
+	if !v3eq(tp.Normal, want.Normal) || !feq(tp.D, want.D) {
+		t.Error("Plane Transform()", tp, want)
+	}
+}
+
+func TestClosest(t *testing.T) {
+	l := Line{V3{0, 0, 0}, V3{4, 0, 0}}
+	if p, at := l.ClosestPoint(V3{1, 3, 0}); !v3eq(p, V3{1, 0, 0}) || !feq(at, 0.25) {
+		t.Error("Line ClosestPoint()", p, at)
+	}
+	if p, at := l.ClosestPoint(V3{-2, 1, 0}); !v3eq(p, V3{}) || at != 0 {
+		t.Error("Line ClosestPoint() before Start", p, at)
+	}
+	if !feq(l.Dist(V3{7, 4, 0}), 5) {
+		t.Error("Line Dist()")
+	}
+
+	// crossing, skew, parallel and degenerate segments
+	a, b := l.ClosestPoints(Line{V3{2, -1, 3}, V3{2, 1, 3}})
+	if !v3eq(a, V3{2, 0, 0}) || !v3eq(b, V3{2, 0, 3}) {
+		t.Error("Line ClosestPoints() skew", a, b)
+	}
+	a, b = l.ClosestPoints(Line{V3{6, 2, 0}, V3{9, 5, 0}})
+	if !v3eq(a, V3{4, 0, 0}) || !v3eq(b, V3{6, 2, 0}) {
+		t.Error("Line ClosestPoints() clamped", a, b)
+	}
+	if d := l.DistLine(Line{V3{1, 2, 0}, V3{3, 2, 0}}); !feq(d, 2) {
+		t.Error("Line DistLine() parallel", d)
+	}
+	if d := l.DistLine(Line{V3{1, 0, 1}, V3{1, 0, 1}}); !feq(d, 1) {
+		t.Error("Line DistLine() point", d)
+	}
+
+	r := Ray{V3{0, 5, -1}, V3{0, 0, 1}}
+	if d := r.DistLine(Line{V3{-1, 0, 2}, V3{1, 0, 2}}); !feq(d, 5) {
+		t.Error("Ray DistLine()", d)
+	}
+	if a, _ := r.ClosestPoints(Line{V3{-1, 0, -5}, V3{1, 0, -5}}); !v3eq(a, r.Origin) {
+		t.Error("Ray ClosestPoints() behind the origin", a)
+	}
+
+	tri := Triangle{V3{0, 0, 0}, V3{2, 0, 0}, V3{0, 2, 0}}
+	for _, test := range []struct {
+		p, want, bary V3
+	}{
+		{V3{0.5, 0.5, 3}, V3{0.5, 0.5, 0}, V3{0.5, 0.25, 0.25}},
+		{V3{-1, -1, 0}, V3{0, 0, 0}, V3{1, 0, 0}},
+		{V3{3, -1, 1}, V3{2, 0, 0}, V3{0, 1, 0}},
+		{V3{1, -1, 0}, V3{1, 0, 0}, V3{0.5, 0.5, 0}},
+		{V3{-1, 1, 0}, V3{0, 1, 0}, V3{0.5, 0, 0.5}},
+		{V3{2, 2, 0}, V3{1, 1, 0}, V3{0, 0.5, 0.5}},
+	} {
+		p, bary := tri.ClosestPoint(test.p)
+		if !v3eq(p, test.want) || !v3eq(bary, test.bary) {
+			t.Error("Triangle ClosestPoint()", test.p, p, bary)
+		}
+		if !v3eq(tri.A.Scale(bary.X).Add(tri.B.Scale(bary.Y)).Add(tri.C.Scale(bary.Z)), p) {
+			t.Error("Triangle ClosestPoint() barycentric mismatch", bary)
+		}
+	}
+
+	box := AABB3{V3{-1, -1, -1}, V3{1, 1, 1}}
+	if !feq(box.Dist(V3{4, 5, 0}), 5) || box.Dist(V3{0.5, 0, 0}) != 0 {
+		t.Error("AABB3 Dist()")
+	}
+
+	obb := OBB{V3{10, 0, 0}, AxisAngleQ(V3{0, 0, 1}, math.Pi/4).M33(), V3{1, 1, 1}}
+	if p := obb.ClosestPoint(V3{10, 5, 0}); !v3eq(p, V3{10, math.Sqrt2, 0}) {
+		t.Error("OBB ClosestPoint()", p)
+	}
+	if !feq(obb.Dist(V3{10, 5, 0}), 5-math.Sqrt2) || obb.Dist(V3{10.5, 0, 0.5}) != 0 {
+		t.Error("OBB Dist()")
+	}
+}
+
+func TestLine2(t *testing.T) {
+	l := Line2{V2{0, 0}, V2{4, 4}}
+	for _, test := range []struct {
+		o    Line2
+		want Line2
+		ok   bool
+	}{
+		{Line2{V2{0, 4}, V2{4, 0}}, Line2{V2{2, 2}, V2{2, 2}}, true},
+		{Line2{V2{0, 4}, V2{1, 3}}, Line2{}, false},
+		{Line2{V2{0, 1}, V2{4, 5}}, Line2{}, false},
+		{Line2{V2{6, 6}, V2{2, 2}}, Line2{V2{2, 2}, V2{4, 4}}, true},
+		{Line2{V2{-1, -1}, V2{5, 5}}, l, true},
+		{Line2{V2{5, 5}, V2{6, 6}}, Line2{}, false},
+		{Line2{V2{4, 4}, V2{6, 6}}, Line2{V2{4, 4}, V2{4, 4}}, true},
+		{Line2{V2{1, 1}, V2{1, 1}}, Line2{V2{1, 1}, V2{1, 1}}, true},
+		{Line2{V2{1, 2}, V2{1, 2}}, Line2{}, false},
+	} {
+		got, ok := l.Intersect(test.o)
+		if ok != test.ok || !v2eq(got.Start, test.want.Start) || !v2eq(got.End, test.want.End) {
+			t.Error("Line2 Intersect()", test.o, got, ok)
+		}
+	}
+
+	p := Line2{V2{1, 1}, V2{1, 1}}
+	if got, ok := p.Intersect(l); !ok || got != p {
+		t.Error("Line2 Intersect() point first", got, ok)
+	}
+}
+
+func TestPolygon(t *testing.T) {
+	square := Polygon{{0, 0}, {2, 0}, {2, 2}, {0, 2}}
+	if !feq(square.SignedArea(), 4) || !square.CCW() || square.Reverse().CCW() || !feq(square.Reverse().Area(), 4) {
+		t.Error("Polygon SignedArea() CCW()")
+	}
+	if c := (Polygon{{0, 0}, {3, 0}, {0, 3}}).Centroid(); !v2eq(c, V2{1, 1}) {
+		t.Error("Polygon Centroid()", c)
+	}
+	if c := (Polygon{{0, 0}, {2, 2}}).Centroid(); !v2eq(c, V2{1, 1}) {
+		t.Error("Polygon Centroid() no area", c)
+	}
+
+	if !square.ContainsEvenOdd(V2{1, 1}) || square.ContainsEvenOdd(V2{3, 1}) || !square.Reverse().ContainsNonZero(V2{1, 1}) {
+		t.Error("Polygon Contains")
+	}
+	if square.WindingNumber(V2{1, 1}) != 1 || square.Reverse().WindingNumber(V2{1, 1}) != -1 {
+		t.Error("Polygon WindingNumber()")
+	}
+
+	// a pentagram's middle is inside by nonzero but not by even-odd
+	var star Polygon
+	for i := 0; i < 5; i++ {
+		a := float64(i*2) * τ / 5
+		star = append(star, V2{math.Cos(a), math.Sin(a)})
+	}
+	if star.WindingNumber(V2{}) != 2 || !star.ContainsNonZero(V2{}) || star.ContainsEvenOdd(V2{}) {
+		t.Error("Polygon pentagram", star.WindingNumber(V2{}))
+	}
+	if !star.ContainsNonZero(V2{0.6, 0.1}) || !star.ContainsEvenOdd(V2{0.6, 0.1}) {
+		t.Error("Polygon pentagram point")
+	}
+
+	hull := ConvexHull([]V2{{1, 1}, {0, 0}, {2, 0}, {1, 0}, {2, 2}, {0, 2}, {0.5, 1.5}, {2, 2}})
+	if len(hull) != 4 || hull[0] != (V2{0, 0}) || hull[1] != (V2{2, 0}) || hull[2] != (V2{2, 2}) || hull[3] != (V2{0, 2}) {
+		t.Error("ConvexHull()", hull)
+	}
+	if hull := ConvexHull([]V2{{1, 1}, {0, 0}, {2, 2}, {1, 1}}); len(hull) != 2 {
+		t.Error("ConvexHull() collinear", hull)
+	}
+	if hull := ConvexHull([]V2{{1, 1}, {1, 1}, {1, 1}}); len(hull) != 1 {
+		t.Error("ConvexHull() one point", hull)
+	}
+}
+
+func TestVectorParity(t *testing.T) {
+	a := V4{1, -2, 3, -4}
+	b := V4{2, 2, 2, 2}
+	if a.Add(b) != (V4{3, 0, 5, -2}) || a.Sub(b) != (V4{-1, -4, 1, -6}) || a.Scale(2) != (V4{2, -4, 6, -8}) || a.Mult(b) != (V4{2, -4, 6, -8}) {
+		t.Error("V4 arithmetic")
+	}
+	if a.Dot(b) != -4 || !feq(a.Len(), math.Sqrt(30)) || !feq(b.Normalize().Len(), 1) || (V4{}).Normalize() != (V4{}) {
+		t.Error("V4 Dot() Len() Normalize()")
+	}
+	if a.Min(b) != (V4{1, -2, 2, -4}) || a.Max(b) != (V4{2, 2, 3, 2}) || a.Abs() != (V4{1, 2, 3, 4}) {
+		t.Error("V4 Min() Max() Abs()")
+	}
+	if a.Clamp(V4{0, 0, 0, 0}, V4{2, 2, 2, 2}) != (V4{1, 0, 2, 0}) || a.Lerp(b, 0.5) != (V4{1.5, 0, 2.5, -1}) {
+		t.Error("V4 Clamp() Lerp()")
+	}
+	if a.XYZ() != (V3{1, -2, 3}) || a.XY() != (V2{1, -2}) || !a.Eq(a) || a.Eq(b) {
+		t.Error("V4 XYZ() XY() Eq()")
+	}
+
+	v := V3{1, 2, 3}
+	if v.XY() != (V2{1, 2}) || v.V4(1) != v.CartesianToHomogeneous() || v.XY().V3(3) != v {
+		t.Error("V3 XY() V4() V2 V3()")
+	}
+	if v.Lerp(V3{3, 2, 1}, 0.25) != (V3{1.5, 2, 2.5}) || (V3{-1, 5, 0}).Clamp(V3{}, V3{2, 2, 2}) != (V3{0, 2, 0}) || (V3{-1, 2, -3}).Abs() != v {
+		t.Error("V3 Lerp() Clamp() Abs()")
+	}
+
+	x := V2{1, 0}
+	y := V2{0, 1}
+	if x.Perp() != y || !v2eq(x.Rotate(math.Pi/2), y) || !v2eq(V2{1, 1}.Rotate(math.Pi), V2{-1, -1}) {
+		t.Error("V2 Perp() Rotate()")
+	}
+	if !feq(float64(y.Angle()), math.Pi/2) || !feq(float64(y.AngleTo(x)), -math.Pi/2) || !feq(float64(x.AngleTo(V2{-1, 1})), 3*math.Pi/4) {
+		t.Error("V2 Angle() AngleTo()")
+	}
+	if (V2{1, -1}).Reflect(y) != (V2{1, 1}) || (V2{3, 4}).Project(x) != (V2{3, 0}) || (V2{3, 4}).Project(V2{}) != (V2{}) {
+		t.Error("V2 Reflect() Project()")
+	}
+	if (V2{2, 3}).Mult(V2{4, 5}) != (V2{8, 15}) || x.Lerp(y, 0.5) != (V2{0.5, 0.5}) || (V2{-2, 3}).Abs() != (V2{2, 3}) || (V2{-2, 3}).Clamp(V2{}, V2{1, 1}) != (V2{0, 1}) {
+		t.Error("V2 Mult() Lerp() Abs() Clamp()")
+	}
+	if !x.Eq(V2{1, 0}) || x.Eq(y) {
+		t.Error("V2 Eq()")
+	}
+}
+
+func TestM23(t *testing.T) {
+	p := V2{3, -1}
+	if TranslateM23(V2{1, 2}).MultV2(p) != (V2{4, 1}) || TranslateM23(V2{1, 2}).MultDir(p) != p {
+		t.Error("TranslateM23()")
+	}
+	if !v2eq(RotateM23(math.Pi/2).MultV2(p), V2{1, 3}) || ScaleM23(V2{2, 3}).MultV2(p) != (V2{6, -3}) {
+		t.Error("RotateM23() ScaleM23()")
+	}
+	if !v2eq(SkewM23(math.Pi/4, 0).MultV2(V2{0, 2}), V2{2, 2}) {
+		t.Error("SkewM23()")
+	}
+
+	a := TranslateM23(V2{5, -2}).Mult(RotateM23(0.4)).Mult(SkewM23(0.3, 0.1)).Mult(ScaleM23(V2{2, 0.5}))
+	b := RotateM23(-1).Mult(ScaleM23(V2{-1, 3}))
+	if !v2eq(a.Mult(b).MultV2(p), a.MultV2(b.MultV2(p))) {
+		t.Error("M23 Mult() order")
+	}
+	if a.M33().M23() != a || a.At(1, 2) != -2 || a.TranslatePart() != (V2{5, -2}) {
+		t.Error("M23 M33() At()")
+	}
+	if q := a.M33().MultV3(p.V3(1)); !v2eq(q.XY(), a.MultV2(p)) || !feq(q.Z, 1) {
+		t.Error("M23 M33() homogeneous", q)
+	}
+	if q := a.M44().MultV3(p.V3(7)); !v2eq(q.XY(), a.MultV2(p)) || !feq(q.Z, 7) {
+		t.Error("M23 M44()", q)
+	}
+	if !feq(a.Determinant(), a.M33().Determinant()) {
+		t.Error("M23 Determinant()")
+	}
+
+	if !v2eq(a.Inverse().MultV2(a.MultV2(p)), p) {
+		t.Error("M23 Inverse()")
+	}
+	if i, ok := (M23{1, 2, 0, 2, 4, 0}).InverseOK(); ok || i != IdentityM23() {
+		t.Error("M23 InverseOK() singular")
+	}
+
+	for _, m := range []M23{a, b, IdentityM23(), ScaleM23(V2{-2, -2})} {
+		translate, rotate, scale, skew := m.Decompose()
+		c := ComposeM23(translate, rotate, scale, skew)
+		for i := range c {
+			if !feq(c[i], m[i]) {
+				t.Error("M23 Decompose() round trip", m, c)
+				break
+			}
+		}
+	}
+	translate, rotate, scale, skew := ComposeM23(V2{1, 2}, 0.5, V2{2, 3}, 0.25).Decompose()
+	if !v2eq(translate, V2{1, 2}) || !feq(float64(rotate), 0.5) || !v2eq(scale, V2{2, 3}) || !feq(skew, 0.25) {
+		t.Error("M23 Decompose()", translate, rotate, scale, skew)
+	}
+}
+
+func TestApproxEqual(t *testing.T) {
+	if !ApproxEqual(1, 1.05, 0.1) || ApproxEqual(1, 1.2, 0.1) || !ApproxEqual(0, -1e-20, 1e-12) {
+		t.Error("ApproxEqual()")
+	}
+
+	inf := math.Inf(1)
+	if !ApproxEqual(inf, inf, 1) || ApproxEqual(inf, -inf, 1) || ApproxEqual(inf, math.MaxFloat64, 1) || ApproxEqual(math.NaN(), math.NaN(), inf) {
+		t.Error("ApproxEqual() infinities and NaN")
+	}
+	if !(V3{X: inf}).ApproxEqual(V3{X: inf}, 1) || !(V3{X: inf}).ApproxEqualULP(V3{X: inf}, 0) || !(V3{X: inf}).Eq(V3{X: inf}) {
+		t.Error("V3 ApproxEqual() infinities")
+	}
+
+	next := math.Nextafter(1, 2)
+	if !ApproxEqualULP(1, next, 1) || ApproxEqualULP(1, math.Nextafter(next, 2), 1) {
+		t.Error("ApproxEqualULP() neighbours")
+	}
+	if !ApproxEqualULP(1e300, 1e300*(1+4*epsilon), 8) || ApproxEqualULP(1e300, 1.0001e300, 8) {
+		t.Error("ApproxEqualULP() is relative")
+	}
+	if !ApproxEqualULP(0, math.Copysign(0, -1), 0) || !ApproxEqualULP(-math.SmallestNonzeroFloat64, math.SmallestNonzeroFloat64, 2) {
+		t.Error("ApproxEqualULP() across zero")
+	}
+	if ApproxEqualULP(math.NaN(), math.NaN(), math.MaxUint64) || ApproxEqualULP(-1, 1, 1000) {
+		t.Error("ApproxEqualULP() NaN and signs")
+	}
+
+	// constants are exact, so make sure this is done in float64
+	a, b := 0.1, 0.2
+	if !(V2{1, a + b}).ApproxEqualULP(V2{1, 0.3}, 1) || (V2{1, a + b}).ApproxEqualULP(V2{1, 0.3}, 0) || (V2{1, 2}).ApproxEqual(V2{1, 2.1}, 0.01) {
+		t.Error("V2 ApproxEqual")
+	}
+	if !(V3{a + b, 0, 1}).ApproxEqualULP(V3{0.3, 0, 1}, 1) || (V3{a + b, 0, 1}).Eq(V3{0.3, 0, 1}) || !(V3{a + b, 1e-17, 1}).ApproxEqual(V3{0.3, 0, 1}, 1e-12) {
+		t.Error("V3 ApproxEqual")
+	}
+	if !(V4{1, 2, 3, 4}).ApproxEqual(V4{1, 2, 3, 4.0001}, 0.001) || (V4{1, 2, 3, 4}).ApproxEqualULP(V4{1, 2, 3, 4.0001}, 1000) {
+		t.Error("V4 ApproxEqual")
+	}
+
+	q := AxisAngleQ(V3{0, 1, 0}, 1)
+	if !q.ApproxEqual(q.Scale(-1), 1e-12) || !q.ApproxEqualULP(q.Scale(-1), 0) || q.ApproxEqual(q.Conjugate(), 1e-3) {
+		t.Error("Q ApproxEqual() sign")
+	}
+
+	m := RotateQM34(q, V3{1, 2, 3}).M44()
+	if !m.Mult(m.Inverse()).ApproxEqual(IdentityM44(), 1e-12) || m.ApproxEqualULP(IdentityM44(), 1000) {
+		t.Error("M44 ApproxEqual()")
+	}
+	r := q.M33()
+	if !r.Mult(r.Transpose()).ApproxEqual(IdentityM33(), 1e-12) || !r.ApproxEqualULP(r, 0) {
+		t.Error("M33 ApproxEqual()")
+	}
+}
+
+func TestSampling(t *testing.T) {
+	lr := rand.New(rand.NewSource(1))
+	const n = 20000
+
+	var mean V3
+	for i := 0; i < n; i++ {
+		v := RandUnitV3(lr)
+		if !feq(v.Len(), 1) {
+			t.Fatal("RandUnitV3() not on the sphere", v)
+		}
+		mean = mean.Add(v)
+	}
+	if mean.Scale(1.0/n).Len() > 0.02 {
+		t.Error("RandUnitV3() not uniform", mean.Scale(1.0/n))
+	}
+
+	// cosine weighted: the average cos(θ) is 2/3
+	normal := V3{1, 2, -2}.Normalize()
+	var cos float64
+	for i := 0; i < n; i++ {
+		v := RandHemisphereV3(lr, normal)
+		if !feq(v.Len(), 1) || v.Dot(normal) < 0 {
+			t.Fatal("RandHemisphereV3()", v)
+		}
+		cos += v.Dot(normal)
+	}
+	if !ApproxEqual(cos/n, 2.0/3, 0.01) {
+		t.Error("RandHemisphereV3() not cosine weighted", cos/n)
+	}
+
+	var inner int
+	for i := 0; i < n; i++ {
+		d := RandDiskV2(lr)
+		if d.Len() > 1 {
+			t.Fatal("RandDiskV2() outside the disk", d)
+		}
+		if d.Len() < 0.5 {
+			inner++
+		}
+	}
+	if !ApproxEqual(float64(inner)/n, 0.25, 0.01) {
+		t.Error("RandDiskV2() not uniform", float64(inner)/n)
+	}
+	if concentricDisk(0.5, 0.5) != (V2{}) || !v2eq(concentricDisk(1, 0.5), V2{1, 0}) || !v2eq(concentricDisk(0.5, 1), V2{0, 1}) {
+		t.Error("concentricDisk()")
+	}
+
+	axis := V3{0, 0, -1}
+	for i := 0; i < 1000; i++ {
+		v := RandConeV3(lr, axis, 0.3)
+		if !feq(v.Len(), 1) || v.Dot(axis) < math.Cos(0.3)-_precision {
+			t.Fatal("RandConeV3() outside the cone", v)
+		}
+	}
+
+	for _, n := range []V3{{0, 0, 1}, {0, 0, -1}, V3{1, 1, 1}.Normalize()} {
+		a, b := basis(n)
+		if !feq(a.Len(), 1) || !feq(b.Len(), 1) || !feq(a.Dot(b), 0) || !v3eq(a.Cross(b), n) {
+			t.Error("basis()", n, a, b)
+		}
+	}
+
+	// a uniform rotation takes a fixed vector anywhere with equal chance
+	mean = V3{}
+	for i := 0; i < n; i++ {
+		q := RandQ(lr)
+		if !feq(q.Len(), 1) {
+			t.Fatal("RandQ() not normalized", q)
+		}
+		mean = mean.Add(q.M33().MultV3(V3{0, 1, 0}))
+	}
+	if mean.Scale(1.0/n).Len() > 0.02 {
+		t.Error("RandQ() not uniform", mean.Scale(1.0/n))
+	}
+
+	tri := Triangle{V3{0, 0, 0}, V3{3, 0, 0}, V3{0, 3, 0}}
+	mean = V3{}
+	for i := 0; i < n; i++ {
+		p := tri.RandPoint(lr)
+		if p.X < 0 || p.Y < 0 || p.X+p.Y > 3+_precision || p.Z != 0 {
+			t.Fatal("Triangle RandPoint() outside", p)
+		}
+		mean = mean.Add(p)
+	}
+	if !mean.Scale(1.0/n).ApproxEqual(V3{1, 1, 0}, 0.03) {
+		t.Error("Triangle RandPoint() not uniform", mean.Scale(1.0/n))
+	}
+
+	box := AABB3{V3{-1, 2, 3}, V3{1, 3, 5}}
+	for i := 0; i < 1000; i++ {
+		if p := box.RandPoint(lr); !box.Contains(p) {
+			t.Fatal("AABB3 RandPoint() outside", p)
+		}
+	}
+
+	if RadicalInverse(1, 2) != 0.5 || RadicalInverse(6, 2) != 0.375 || !feq(RadicalInverse(5, 3), 7.0/9) {
+		t.Error("RadicalInverse()")
+	}
+	for _, base := range []uint32{0, 1} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Error("RadicalInverse() didn't panic with base", base)
+				}
+			}()
+			RadicalInverse(3, base)
+		}()
+	}
+	if Halton(3) != (V2{0.75, 1.0 / 9}) || !v3eq(Halton3(4), V3{0.125, 4.0 / 9, 0.8}) {
+		t.Error("Halton()", Halton(3), Halton3(4))
+	}
+	if Hammersley(3, 8) != (V2{0.375, 0.75}) {
+		t.Error("Hammersley()", Hammersley(3, 8))
+	}
+
+	// the first four Sobol points land one in each quarter of each axis
+	want := []V2{{0, 0}, {0.5, 0.5}, {0.25, 0.75}, {0.75, 0.25}}
+	for i, w := range want {
+		if s := Sobol(uint32(i)); s != w {
+			t.Error("Sobol()", i, s)
+		}
+	}
+	var xs, ys [16]int
+	for i := uint32(16); i < 32; i++ {
+		s := Sobol(i)
+		xs[int(s.X*16)]++
+		ys[int(s.Y*16)]++
+	}
+	for i := range xs {
+		if xs[i] != 1 || ys[i] != 1 {
+			t.Error("Sobol() not stratified", xs, ys)
+			break
+		}
+	}
+}